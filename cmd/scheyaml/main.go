@@ -0,0 +1,364 @@
+// Command scheyaml generates an example YAML configuration file from a JSON schema, using the descriptions and
+// examples of the schema as comments and the default values as values.
+//
+// Usage:
+//
+//	scheyaml [flags] <schema>
+//	scheyaml validate <schema> <file>...
+//
+// The schema may be written in JSON or YAML. Override values are read from YAML or JSON files using --values,
+// from environment variables using --env-prefix and/or given on the command line using --set, in increasing
+// order of precedence. The --values flag can be repeated, in which case the files are merged in order. The
+// values of --set and environment variables are converted to the type of the schema.
+//
+// The validate subcommand validates YAML files against the schema and prints an error for every violation
+// in the form file:line:column: path: message.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/survivorbat/go-scheyaml"
+	"gopkg.in/yaml.v3"
+)
+
+// outputPermissions are used when writing the output to a file, which may contain secrets from the overrides
+const outputPermissions = 0o600
+
+var (
+	// errUsage is returned if the command line arguments are invalid
+	errUsage = errors.New("invalid usage")
+
+	// errInvalidFile is returned if a file does not comply with the schema
+	errInvalidFile = errors.New("file does not match the schema")
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments and returns the exit code
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "validate" {
+		return runValidate(args[1:], stderr)
+	}
+
+	flags := flag.NewFlagSet("scheyaml", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "Usage: scheyaml [flags] <schema>")
+		flags.PrintDefaults()
+	}
+
+	var (
+		sets            setFlag
+		valuesFiles     setFlag
+		output          = flags.String("output", "", "write the result to `file` instead of stdout")
+		header          = flags.String("schema-header", "", "add a yaml-language-server header referring to the schema at `path`")
+		todoComment     = flags.String("todo-comment", "", "comment added to properties without a default value")
+		indent          = flags.Int("indent", 0, "number of spaces used for indentation")
+		lineLength      = flags.Uint("comment-max-length", 0, "maximum length of comment lines, 0 disables wrapping")
+		onlyRequired    = flags.Bool("only-required", false, "only output required properties")
+		skipValidate    = flags.Bool("skip-validate", false, "don't validate the override values against the schema")
+		optional        = flags.Bool("optional-as-comments", false, "render optional properties as comments")
+		constraints     = flags.Bool("constraint-comments", false, "add the validation constraints of properties to their comments")
+		enumPlaceholder = flags.Bool("enum-placeholder", false, "use the first enum value of properties without a default")
+		skipDeprecated  = flags.Bool("skip-deprecated", false, "leave out deprecated properties")
+		includeReadOnly = flags.Bool("include-read-only", false, "output readOnly properties")
+		showWriteOnly   = flags.Bool("include-write-only", false, "output the values of writeOnly properties")
+		writeOnly       = flags.String("write-only-placeholder", "<secret>", "render `placeholder` instead of the values of writeOnly properties")
+		emptyArrays     = flags.Bool("empty-arrays", false, "render arrays without values as [] instead of a placeholder item")
+		provenance      = flags.Bool("provenance-comments", false, "add a comment to every value that describes where it came from")
+		asJSON          = flags.Bool("json", false, "output the resolved values as JSON instead of YAML")
+		envPrefix       = flags.String("env-prefix", "", "read override values from environment variables starting with `prefix`, e.g. APP_DB__PORT")
+	)
+
+	flags.Var(&valuesFiles, "values", "YAML or JSON `file` with override values (can be repeated, later files take precedence)")
+	flags.Var(&sets, "set", "override a value using `path=value`, e.g. database.port=5432 (can be repeated)")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+
+		return 2 //nolint:mnd // exit code for invalid usage
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return 2 //nolint:mnd // exit code for invalid usage
+	}
+
+	// only the flags that were given override the defaults of the library
+	var opts []scheyaml.Option
+
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "schema-header":
+			opts = append(opts, scheyaml.WithSchemaHeader(*header))
+		case "todo-comment":
+			opts = append(opts, scheyaml.WithTODOComment(*todoComment))
+		case "indent":
+			opts = append(opts, scheyaml.WithIndent(*indent))
+		case "comment-max-length":
+			opts = append(opts, scheyaml.WithCommentMaxLength(*lineLength))
+		case "only-required":
+			if *onlyRequired {
+				opts = append(opts, scheyaml.OnlyRequired())
+			}
+		case "env-prefix":
+			opts = append(opts, scheyaml.WithEnvOverrides(*envPrefix))
+		case "optional-as-comments":
+			if *optional {
+				opts = append(opts, scheyaml.WithOptionalAsComments())
+			}
+		case "constraint-comments":
+			if *constraints {
+				opts = append(opts, scheyaml.WithConstraintComments())
+			}
+		case "enum-placeholder":
+			if *enumPlaceholder {
+				opts = append(opts, scheyaml.WithEnumPlaceholder())
+			}
+		case "skip-deprecated":
+			if *skipDeprecated {
+				opts = append(opts, scheyaml.SkipDeprecated())
+			}
+		case "include-read-only":
+			if *includeReadOnly {
+				opts = append(opts, scheyaml.IncludeReadOnly())
+			}
+		case "include-write-only":
+			if *showWriteOnly {
+				opts = append(opts, scheyaml.IncludeWriteOnly())
+			}
+		case "write-only-placeholder":
+			opts = append(opts, scheyaml.WithWriteOnlyPlaceholder(*writeOnly))
+		case "empty-arrays":
+			if *emptyArrays {
+				opts = append(opts, scheyaml.WithEmptyArrays())
+			}
+		case "provenance-comments":
+			if *provenance {
+				opts = append(opts, scheyaml.WithProvenanceComments())
+			}
+		case "skip-validate":
+			if *skipValidate {
+				opts = append(opts, scheyaml.SkipValidate())
+			}
+		}
+	})
+
+	result, err := generate(flags.Arg(0), valuesFiles, sets, *asJSON, opts)
+	if err == nil {
+		err = write(result, *output, stdout)
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "scheyaml: %s\n", err)
+
+		if errors.Is(err, errUsage) {
+			return 2 //nolint:mnd // exit code for invalid usage
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+// runValidate executes the validate subcommand and returns the exit code, diagnostics are written to stderr
+func runValidate(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("scheyaml validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "Usage: scheyaml validate <schema> <file>...")
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+
+		return 2 //nolint:mnd // exit code for invalid usage
+	}
+
+	if flags.NArg() < 2 { //nolint:mnd // schema and at least one file
+		flags.Usage()
+
+		return 2 //nolint:mnd // exit code for invalid usage
+	}
+
+	schema, err := loadSchema(flags.Arg(0))
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "scheyaml: %s\n", err)
+
+		return 1
+	}
+
+	code := 0
+
+	for _, file := range flags.Args()[1:] {
+		if err := validate(schema, file, stderr); err != nil {
+			_, _ = fmt.Fprintf(stderr, "scheyaml: %s\n", err)
+			code = 1
+		}
+	}
+
+	return code
+}
+
+// validate the file against the schema, printing a diagnostic for every violation
+func validate(schema *jsonschema.Schema, file string, stderr io.Writer) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	err = scheyaml.ValidateYAML(schema, data)
+
+	var validationErr *scheyaml.ValidationError
+	if !errors.As(err, &validationErr) {
+		if err != nil {
+			return fmt.Errorf("failed to validate %s: %w", file, err)
+		}
+
+		return nil
+	}
+
+	for _, diagnostic := range validationErr.Diagnostics {
+		_, _ = fmt.Fprintf(stderr, "%s:%s\n", file, diagnostic)
+	}
+
+	return fmt.Errorf("%s: %d error(s): %w", file, len(validationErr.Diagnostics), errInvalidFile)
+}
+
+// generate compiles the schema and returns the YAML (or JSON) output with the overrides of the values files and the
+// sets applied
+func generate(schemaFile string, valuesFiles setFlag, sets setFlag, asJSON bool, opts []scheyaml.Option) ([]byte, error) {
+	schema, err := loadSchema(schemaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := make([]map[string]any, 0, len(valuesFiles))
+
+	for _, valuesFile := range valuesFiles {
+		data, err := os.ReadFile(valuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values: %w", err)
+		}
+
+		var layer map[string]any
+		if err := yaml.Unmarshal(data, &layer); err != nil {
+			return nil, fmt.Errorf("failed to parse values %q: %w", valuesFile, err)
+		}
+
+		layers = append(layers, layer)
+	}
+
+	paths, err := parseSets(sets)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, scheyaml.WithOverrideLayers(layers...), scheyaml.WithOverridePaths(paths))
+
+	if asJSON {
+		result, err := scheyaml.SchemaToJSON(schema, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate json: %w", err)
+		}
+
+		return result, nil
+	}
+
+	result, err := scheyaml.SchemaToYAML(schema, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate yaml: %w", err)
+	}
+
+	return result, nil
+}
+
+// loadSchema reads and compiles the schema, YAML schemas are converted to JSON first
+func loadSchema(schemaFile string) (*jsonschema.Schema, error) {
+	data, err := os.ReadFile(schemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	if !json.Valid(data) {
+		var document any
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("failed to parse schema %q: %w", schemaFile, err)
+		}
+
+		if data, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("failed to convert schema %q to json: %w", schemaFile, err)
+		}
+	}
+
+	schema, err := jsonschema.NewCompiler().Compile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %q: %w", schemaFile, err)
+	}
+
+	return schema, nil
+}
+
+// write the result to the output file, or to stdout if no file was given
+func write(result []byte, output string, stdout io.Writer) error {
+	if output == "" {
+		if _, err := stdout.Write(result); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(output, result, outputPermissions); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// setFlag collects the values of a repeated flag
+type setFlag []string
+
+// String returns the values joined by a comma
+func (s *setFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set adds a value
+func (s *setFlag) Set(value string) error {
+	*s = append(*s, value)
+
+	return nil
+}
+
+// parseSets parses the `path=value` expressions into override paths
+func parseSets(sets setFlag) (map[string]any, error) {
+	result := make(map[string]any, len(sets))
+
+	for _, set := range sets {
+		path, value, ok := strings.Cut(set, "=")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("--set %q is not of the form path=value: %w", set, errUsage)
+		}
+
+		result[path] = value
+	}
+
+	return result, nil
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_WritesExpectedOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args []string
+
+		expected string
+	}{
+		"defaults without validation": {
+			args: []string{"--skip-validate", path.Join("testdata", "schema.yaml")},
+
+			expected: `database:
+    host: localhost
+    port: 5432
+# The name of the service
+name: example
+`,
+		},
+		"only required with header": {
+			args: []string{"--only-required", "--schema-header", "schema.yaml", "--set", "name=service", path.Join("testdata", "schema.yaml")},
+
+			expected: `# yaml-language-server: $schema=schema.yaml
+# The name of the service
+name: service
+`,
+		},
+		"values file and sets": {
+			args: []string{
+				"--values", path.Join("testdata", "values.json"),
+				"--set", "database.port=6543",
+				"--set", "name=service",
+				"--indent", "2",
+				path.Join("testdata", "schema.yaml"),
+			},
+
+			expected: `database:
+  host: db.example.com
+  port: 6543
+# The name of the service
+name: service
+`,
+		},
+		"values files are merged in order": {
+			args: []string{
+				"--values", path.Join("testdata", "values.json"),
+				"--values", path.Join("testdata", "production.yaml"),
+				path.Join("testdata", "schema.yaml"),
+			},
+
+			expected: `database:
+    host: db.example.com
+    port: 6432
+# The name of the service
+name: production
+`,
+		},
+		"json output": {
+			args: []string{"--json", "--skip-validate", "--set", "database.port=6543", path.Join("testdata", "schema.yaml")},
+
+			expected: `{
+  "database": {
+    "host": "localhost",
+    "port": 6543
+  },
+  "name": "example"
+}
+`,
+		},
+		"sets are converted to the type of the schema": {
+			args: []string{"--only-required", "--set", "name=123", path.Join("testdata", "schema.yaml")},
+
+			expected: `# The name of the service
+name: "123"
+`,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+			// Act
+			code := run(testData.args, stdout, stderr)
+
+			// Assert
+			assert.Equal(t, 0, code, stderr.String())
+			assert.Equal(t, testData.expected, stdout.String())
+		})
+	}
+}
+
+func TestRun_WritesOutputFile(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	output := path.Join(t.TempDir(), "config.yaml")
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	// Act
+	code := run([]string{"--output", output, "--only-required", "--set", "name=service", path.Join("testdata", "schema.yaml")}, stdout, stderr)
+
+	// Assert
+	require.Equal(t, 0, code, stderr.String())
+	assert.Empty(t, stdout.String())
+
+	result, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "# The name of the service\nname: service\n", string(result))
+}
+
+func TestRun_ReturnsExitCodeOnError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args []string
+
+		expected int
+	}{
+		"no schema": {
+			args:     []string{},
+			expected: 2,
+		},
+		"unknown flag": {
+			args:     []string{"--does-not-exist", path.Join("testdata", "schema.yaml")},
+			expected: 2,
+		},
+		"invalid set": {
+			args:     []string{"--set", "name", path.Join("testdata", "schema.yaml")},
+			expected: 2,
+		},
+		"schema does not exist": {
+			args:     []string{path.Join("testdata", "does-not-exist.json")},
+			expected: 1,
+		},
+		"invalid override": {
+			args:     []string{"--set", "database.port=abc", path.Join("testdata", "schema.yaml")},
+			expected: 1,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+			// Act
+			code := run(testData.args, stdout, stderr)
+
+			// Assert
+			assert.Equal(t, testData.expected, code)
+			assert.Empty(t, stdout.String())
+			assert.NotEmpty(t, stderr.String())
+		})
+	}
+}
+
+func TestParseSets_ReturnsPaths(t *testing.T) {
+	t.Parallel()
+	// Act
+	result, err := parseSets(setFlag{"database.port=5432", "servers[1].host=a=b", "name="})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"database.port": "5432", "servers[1].host": "a=b", "name": ""}, result)
+}
+
+func TestRun_ValidatesFiles(t *testing.T) {
+	t.Parallel()
+
+	schema := path.Join("testdata", "schema.yaml")
+	valid := path.Join("testdata", "valid.yaml")
+	invalid := path.Join("testdata", "invalid.yaml")
+
+	tests := map[string]struct {
+		args []string
+
+		expectedCode   int
+		expectedStderr string
+	}{
+		"valid file": {
+			args: []string{"validate", schema, valid},
+
+			expectedCode:   0,
+			expectedStderr: "",
+		},
+		"invalid file": {
+			args: []string{"validate", schema, valid, invalid},
+
+			expectedCode: 1,
+			expectedStderr: invalid + ":4:3: /database/port: Value is string but should be integer\n" +
+				"scheyaml: " + invalid + ": 1 error(s): file does not match the schema\n",
+		},
+		"file does not exist": {
+			args: []string{"validate", schema, path.Join("testdata", "does-not-exist.yaml")},
+
+			expectedCode: 1,
+			expectedStderr: "scheyaml: failed to read file: open " + path.Join("testdata", "does-not-exist.yaml") +
+				": no such file or directory\n",
+		},
+		"missing file argument": {
+			args: []string{"validate", schema},
+
+			expectedCode:   2,
+			expectedStderr: "Usage: scheyaml validate <schema> <file>...\n",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+			// Act
+			code := run(testData.args, stdout, stderr)
+
+			// Assert
+			assert.Equal(t, testData.expectedCode, code)
+			assert.Empty(t, stdout.String())
+			assert.Equal(t, testData.expectedStderr, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package scheyaml
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+// effectiveSchema folds the composition keywords of the schema (allOf, the selected anyOf/oneOf branch and the
+// then/else of a conditional) into a single schema that can be rendered on its own. The schema itself takes precedence
+// over its allOf members, which take precedence over the selected branch and finally the conditional. If the schema
+// does not use any composition it is returned as-is.
+func effectiveSchema(schema *jsonschema.Schema, cfg *Config) *jsonschema.Schema {
+	return foldSchema(schema, cfg, nil)
+}
+
+// foldSchema implements effectiveSchema, folding contains the schemas that are currently being folded. A schema
+// that (indirectly) refers to itself through its composition keywords is not folded again.
+func foldSchema(schema *jsonschema.Schema, cfg *Config, folding []*jsonschema.Schema) *jsonschema.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	schema = resolveRef(schema)
+	if slices.Contains(folding, schema) {
+		return schema
+	}
+
+	folding = append(slices.Clip(folding), schema)
+
+	members := composedMembers(schema, cfg, folding)
+	if len(members) == 0 && schema.If == nil {
+		return schema
+	}
+
+	merged := mergeSchemas(append([]*jsonschema.Schema{schema}, members...))
+
+	// the if-schema is evaluated against the merged schema, as its members may provide defaults
+	if schema.If != nil {
+		conditional := schema.Else
+		if schema.If.Validate(conditionInstance(merged, cfg, folding)).IsValid() {
+			conditional = schema.Then
+		}
+
+		if conditional != nil {
+			merged = mergeSchemas([]*jsonschema.Schema{merged, foldSchema(conditional, cfg, folding)})
+		}
+	}
+
+	return merged
+}
+
+// conditionInstance returns the value an if-schema is evaluated against, which are the override values of the config
+// completed with the default values of the properties in the schema. Keys that are skipped using SkipValue are left out.
+func conditionInstance(schema *jsonschema.Schema, cfg *Config, folding []*jsonschema.Schema) any {
+	value, hasValue := cfg.currentValue()
+
+	if !slices.Contains(schema.Type, "object") && schema.Properties == nil {
+		if hasValue {
+			return value
+		}
+
+		return schema.Default
+	}
+
+	instance := make(map[string]any, len(cfg.ValueOverrides))
+	for key, override := range cfg.ValueOverrides {
+		if _, shouldSkip := override.(skipValue); !shouldSkip {
+			instance[key] = override
+		}
+	}
+
+	if schema.Properties != nil {
+		for name, property := range *schema.Properties {
+			if _, ok := cfg.ValueOverrides[name]; ok {
+				continue
+			}
+
+			if property = foldSchema(property, cfg.forProperty(name, nil), folding); property != nil && property.Default != nil {
+				instance[name] = property.Default
+			}
+		}
+	}
+
+	return instance
+}
+
+// composedMembers returns the (effective) allOf members of the schema followed by the selected anyOf/oneOf branch
+func composedMembers(schema *jsonschema.Schema, cfg *Config, folding []*jsonschema.Schema) []*jsonschema.Schema {
+	members := make([]*jsonschema.Schema, 0, len(schema.AllOf)+1)
+
+	for _, member := range schema.AllOf {
+		if member != nil {
+			members = append(members, foldSchema(member, cfg, folding))
+		}
+	}
+
+	if branch, ok := selectBranch(schema, cfg); ok {
+		members = append(members, foldSchema(branch, cfg, folding))
+	}
+
+	return members
+}
+
+// resolveRef follows the references of the schema until a schema without a reference is found. If the references
+// are circular, the last schema before the cycle is returned, which still has a reference.
+func resolveRef(schema *jsonschema.Schema) *jsonschema.Schema {
+	seen := []*jsonschema.Schema{schema}
+
+	for schema.Ref != "" && schema.ResolvedRef != nil && !slices.Contains(seen, schema.ResolvedRef) {
+		schema = schema.ResolvedRef
+		seen = append(seen, schema)
+	}
+
+	return schema
+}
+
+// mergeSchemas combines the given schemas into a new schema. For keywords that hold a single value the first schema
+// that defines it wins, properties that are defined by multiple schemas are combined with allOf and the required
+// properties are the union of all required properties. The composition keywords of the result are cleared, as they
+// are considered to be part of the given schemas.
+func mergeSchemas(schemas []*jsonschema.Schema) *jsonschema.Schema {
+	merged := *schemas[0]
+	merged.AllOf, merged.AnyOf, merged.OneOf = nil, nil, nil
+	merged.If, merged.Then, merged.Else = nil, nil, nil
+	merged.Properties = mergeProperties(nil, merged.Properties)
+	merged.PatternProperties = mergePatternProperties(nil, merged.PatternProperties)
+	merged.Required = slices.Clone(merged.Required)
+
+	for _, schema := range schemas[1:] {
+		if len(merged.Type) == 0 {
+			merged.Type = schema.Type
+		}
+
+		if merged.Default == nil {
+			merged.Default = schema.Default
+		}
+
+		if len(merged.Examples) == 0 {
+			merged.Examples = schema.Examples
+		}
+
+		if len(merged.Enum) == 0 {
+			merged.Enum = schema.Enum
+		}
+
+		if len(merged.PrefixItems) == 0 {
+			merged.PrefixItems = schema.PrefixItems
+		}
+
+		fill(&merged.Title, schema.Title)
+		fill(&merged.Description, schema.Description)
+		fill(&merged.Const, schema.Const)
+		fill(&merged.Format, schema.Format)
+		fill(&merged.Items, schema.Items)
+		fill(&merged.Contains, schema.Contains)
+		fill(&merged.AdditionalProperties, schema.AdditionalProperties)
+		fill(&merged.Minimum, schema.Minimum)
+		fill(&merged.Maximum, schema.Maximum)
+		fill(&merged.ExclusiveMinimum, schema.ExclusiveMinimum)
+		fill(&merged.ExclusiveMaximum, schema.ExclusiveMaximum)
+		fill(&merged.MultipleOf, schema.MultipleOf)
+		fill(&merged.MinLength, schema.MinLength)
+		fill(&merged.MaxLength, schema.MaxLength)
+		fill(&merged.Pattern, schema.Pattern)
+		fill(&merged.MinItems, schema.MinItems)
+		fill(&merged.MaxItems, schema.MaxItems)
+		fill(&merged.UniqueItems, schema.UniqueItems)
+		fill(&merged.Deprecated, schema.Deprecated)
+		fill(&merged.ReadOnly, schema.ReadOnly)
+		fill(&merged.WriteOnly, schema.WriteOnly)
+
+		merged.Properties = mergeProperties(merged.Properties, schema.Properties)
+		merged.PatternProperties = mergePatternProperties(merged.PatternProperties, schema.PatternProperties)
+		merged.Required = unique(append(merged.Required, schema.Required...))
+	}
+
+	return &merged
+}
+
+// mergeProperties returns a new map with the properties of both maps, properties that exist in both are combined
+// in an allOf where the property in target takes precedence. The allOf is folded by effectiveSchema once the property
+// is processed, as only then the overrides that determine its branches and conditionals are known.
+func mergeProperties(target *jsonschema.SchemaMap, source *jsonschema.SchemaMap) *jsonschema.SchemaMap {
+	if (target == nil || len(*target) == 0) && (source == nil || len(*source) == 0) {
+		return target
+	}
+
+	result := make(jsonschema.SchemaMap)
+	if target != nil {
+		maps.Copy(result, *target)
+	}
+
+	if source != nil {
+		for name, property := range *source {
+			if existing, ok := result[name]; ok && existing != nil && property != nil {
+				result[name] = &jsonschema.Schema{AllOf: []*jsonschema.Schema{existing, property}}
+				continue
+			}
+
+			result[name] = property
+		}
+	}
+
+	return &result
+}
+
+// mergePatternProperties returns a new map with the pattern properties of both maps, if a pattern exists in both
+// maps the one in target is used.
+func mergePatternProperties(target *jsonschema.SchemaMap, source *jsonschema.SchemaMap) *jsonschema.SchemaMap {
+	if (target == nil || len(*target) == 0) && (source == nil || len(*source) == 0) {
+		return target
+	}
+
+	result := make(jsonschema.SchemaMap)
+	if source != nil {
+		maps.Copy(result, *source)
+	}
+
+	if target != nil {
+		maps.Copy(result, *target)
+	}
+
+	return &result
+}
+
+// fill sets target to source if target is not set yet
+func fill[E any](target **E, source *E) {
+	if *target == nil {
+		*target = source
+	}
+}
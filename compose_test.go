@@ -0,0 +1,91 @@
+package scheyaml
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveSchema_ReturnsSchemaWithoutComposition(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema := &jsonschema.Schema{Type: jsonschema.SchemaType{"string"}}
+
+	// Act
+	result := effectiveSchema(schema, NewConfig())
+
+	// Assert
+	assert.Same(t, schema, result)
+}
+
+func TestEffectiveSchema_NilSchema(t *testing.T) {
+	t.Parallel()
+	// Act
+	result := effectiveSchema(nil, NewConfig())
+
+	// Assert
+	assert.Nil(t, result)
+}
+
+func TestMergeSchemas_FirstSchemaTakesPrecedence(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	first := "first"
+	second := "second"
+
+	schemas := []*jsonschema.Schema{
+		{Description: &first, Required: []string{"a"}},
+		{
+			Type:        jsonschema.SchemaType{"object"},
+			Title:       &second,
+			Description: &second,
+			Default:     map[string]any{"a": 1},
+			Required:    []string{"b"},
+		},
+	}
+
+	// Act
+	result := mergeSchemas(schemas)
+
+	// Assert
+	require.NotNil(t, result)
+	assert.Equal(t, jsonschema.SchemaType{"object"}, result.Type)
+	assert.Equal(t, &first, result.Description)
+	assert.Equal(t, &second, result.Title)
+	assert.Equal(t, map[string]any{"a": 1}, result.Default)
+	assert.ElementsMatch(t, []string{"a", "b"}, result.Required)
+}
+
+func TestMergeSchemas_MergesPropertiesRecursively(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	description := "description"
+
+	schemas := []*jsonschema.Schema{
+		{Properties: &jsonschema.SchemaMap{
+			"a": {Description: &description},
+		}},
+		{Properties: &jsonschema.SchemaMap{
+			"a": {Type: jsonschema.SchemaType{"string"}, Default: "abc"},
+			"b": {Type: jsonschema.SchemaType{"integer"}},
+		}},
+	}
+
+	// Act
+	result := mergeSchemas(schemas)
+
+	// Assert
+	require.NotNil(t, result.Properties)
+	require.Len(t, *result.Properties, 2)
+
+	a := effectiveSchema((*result.Properties)["a"], NewConfig())
+	assert.Equal(t, &description, a.Description)
+	assert.Equal(t, jsonschema.SchemaType{"string"}, a.Type)
+	assert.Equal(t, "abc", a.Default)
+
+	// The input may not be modified
+	assert.Len(t, *schemas[0].Properties, 1)
+	assert.Nil(t, (*schemas[0].Properties)["a"].Default)
+}
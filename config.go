@@ -2,6 +2,8 @@ package scheyaml
 
 import (
 	"reflect"
+	"slices"
+	"strconv"
 
 	"github.com/kaptinlin/jsonschema"
 )
@@ -19,6 +21,14 @@ const defaultTODOComment = "TODO: Fill this in"
 // Also, this docstring is written to not exceed the 80 character limit :)
 const defaultLineLength = 80
 
+// defaultWriteOnlyPlaceholder is rendered instead of the values of writeOnly properties, so secrets such as passwords
+// aren't disclosed by default just like readOnly properties are left out by default
+const defaultWriteOnlyPlaceholder = "<secret>"
+
+// defaultMaxDepth is the number of times a recursive schema may be nested when rendering override values,
+// which is plenty for any reasonable configuration file
+const defaultMaxDepth = 32
+
 // Config serves as the configuration object to allow customisation in the library
 type Config struct {
 	// OutputHeader is added to the top of the generated result as a comment, This property is only available at the root level and not copied in
@@ -40,6 +50,13 @@ type Config struct {
 	// the schema to function.
 	ValueOverrides map[string]any
 
+	// OverrideSources contains the index of the layer each override value came from by path, see WithOverrideLayers
+	OverrideSources map[string]int
+
+	// PathOverrides contains override values by path (e.g. `servers[1].host`), they are merged into ValueOverrides
+	// before processing. This property is only available at the root level and not copied in forProperty
+	PathOverrides map[string]any
+
 	// ItemsOverrides allows a user to override the default values of a schema with the given value(s).
 	// Because a schema may be a slice (of potentially nested maps) this is stored separately from ValueOverrides
 	ItemsOverrides []any
@@ -54,25 +71,88 @@ type Config struct {
 	// OnlyRequired properties are returned
 	OnlyRequired bool
 
+	// ConstraintComments adds the validation constraints of a property (e.g. minimum or pattern) to its comment
+	ConstraintComments bool
+
+	// OptionalAsComments renders the properties that are not required as YAML that is commented out
+	OptionalAsComments bool
+
+	// SkipDeprecated properties, unless they're overridden. By default they're annotated with a DEPRECATED comment
+	SkipDeprecated bool
+
+	// IncludeReadOnly properties, which are left out by default as they're managed by the application itself
+	IncludeReadOnly bool
+
+	// WriteOnlyPlaceholder is rendered instead of the value of writeOnly properties (e.g. passwords), the values
+	// themselves are rendered if empty
+	WriteOnlyPlaceholder string
+
+	// Redaction masks the values of sensitive properties, disabled if nil
+	Redaction *Redaction
+
+	// EmptyArrays renders an empty list instead of a placeholder item for arrays without values, unless the array
+	// requires items using minItems, prefixItems or contains
+	EmptyArrays bool
+
+	// ArrayMerge determines how override items are combined with the default items of an array
+	ArrayMerge ArrayMergeStrategy
+
+	// ArrayMergeDocument is the document the schema was compiled from, used to find the key of MergeItemsByKey
+	ArrayMergeDocument *SchemaDocument
+
+	// ProvenanceComments adds a comment to every scalar that describes where its value came from
+	ProvenanceComments bool
+
+	// EnumPlaceholder renders the first enum value of properties without a default value instead of null
+	EnumPlaceholder bool
+
 	// LineLength prevents descriptions and unreasonably long lines. Can be disabled
 	// completely by setting it to 0.
 	LineLength uint
 
-	// Indent used when marshalling to YAML. This property is only available at the root level and not copied in
-	// forProperty
+	// Indent used when marshalling to YAML, it's also used to render properties that are commented out
 	Indent int
 
 	// SkipValidate of the provided jsonschema and override values. Might result in undefined behavior, use
 	// at own risk. This property is only available at the root level and not copied in forProperty
 	SkipValidate bool
+
+	// PropertyOrder determines the order of the keys of objects, alphabetical if nil
+	PropertyOrder PropertyOrder
+
+	// MaxDepth is the number of times a recursive schema is followed to render the override values, if the
+	// overrides are nested any deeper a MaxDepthError is returned. Without overrides a recursive schema is
+	// rendered as a placeholder.
+	MaxDepth uint
+
+	// path to the node the config was constructed for, used in error messages
+	path string
+
+	// visited contains the schemas that are currently being rendered, used to detect recursion
+	visited []*jsonschema.Schema
+
+	// skipPlaceholders leaves out the placeholder items of arrays, as they're not part of the resolved values
+	skipPlaceholders bool
+
+	// origin of the schema that is being rendered if it's not the schema itself, e.g. a pattern property
+	origin string
+
+	// origins contains the labels of the pattern properties that apply, see WithProvenanceComments
+	origins map[*jsonschema.Schema]string
+
+	// overrideOrigins contains the labels of override values by path that don't come from OverrideSources, such as path
+	// overrides and the default items merged by WithArrayMerge, see WithProvenanceComments
+	overrideOrigins map[string]string
 }
 
 // NewConfig instantiates a config object with default values
 func NewConfig() *Config {
 	return &Config{
-		ValueOverrides: make(map[string]any),
-		TODOComment:    defaultTODOComment,
-		LineLength:     defaultLineLength,
+		ValueOverrides:       make(map[string]any),
+		TODOComment:          defaultTODOComment,
+		WriteOnlyPlaceholder: defaultWriteOnlyPlaceholder,
+		LineLength:           defaultLineLength,
+		MaxDepth:             defaultMaxDepth,
 	}
 }
 
@@ -124,14 +204,35 @@ func (c *Config) forProperty(propertyName string, patternProps []*jsonschema.Sch
 	}
 
 	return &Config{
-		ValueOverride:     valueOverride,
-		HasOverride:       hasValueOverride,
-		ValueOverrides:    valueOverrides,
-		ItemsOverrides:    itemsOverrides,
-		PatternProperties: patterns,
-		TODOComment:       c.TODOComment,
-		OnlyRequired:      c.OnlyRequired,
-		LineLength:        c.LineLength,
+		ValueOverride:        valueOverride,
+		HasOverride:          hasValueOverride,
+		ValueOverrides:       valueOverrides,
+		ItemsOverrides:       itemsOverrides,
+		PatternProperties:    patterns,
+		TODOComment:          c.TODOComment,
+		OnlyRequired:         c.OnlyRequired,
+		OptionalAsComments:   c.OptionalAsComments,
+		SkipDeprecated:       c.SkipDeprecated,
+		IncludeReadOnly:      c.IncludeReadOnly,
+		WriteOnlyPlaceholder: c.WriteOnlyPlaceholder,
+		Redaction:            c.Redaction,
+		EmptyArrays:          c.EmptyArrays,
+		ArrayMerge:           c.ArrayMerge,
+		ArrayMergeDocument:   c.ArrayMergeDocument,
+		OverrideSources:      c.OverrideSources,
+		ProvenanceComments:   c.ProvenanceComments,
+		skipPlaceholders:     c.skipPlaceholders,
+		EnumPlaceholder:      c.EnumPlaceholder,
+		ConstraintComments:   c.ConstraintComments,
+		LineLength:           c.LineLength,
+		Indent:               c.Indent,
+		PropertyOrder:        c.PropertyOrder,
+		MaxDepth:             c.MaxDepth,
+		path:                 joinPath(c.path, propertyName),
+		visited:              c.visited,
+		origin:               c.origin,
+		overrideOrigins:      c.overrideOrigins,
+		origins:              c.inheritedOrigins(propertyName),
 	}
 }
 
@@ -155,17 +256,67 @@ func (c *Config) forIndex(index int) *Config {
 	}
 
 	return &Config{
-		HasOverride:       hasValueOverride,
-		ValueOverride:     valueOverride,
-		ValueOverrides:    valueOverrides,
-		ItemsOverrides:    nil,
-		PatternProperties: nil,
-		TODOComment:       c.TODOComment,
-		OnlyRequired:      c.OnlyRequired,
-		LineLength:        c.LineLength,
+		HasOverride:          hasValueOverride,
+		ValueOverride:        valueOverride,
+		ValueOverrides:       valueOverrides,
+		ItemsOverrides:       nil,
+		PatternProperties:    nil,
+		TODOComment:          c.TODOComment,
+		OnlyRequired:         c.OnlyRequired,
+		OptionalAsComments:   c.OptionalAsComments,
+		SkipDeprecated:       c.SkipDeprecated,
+		IncludeReadOnly:      c.IncludeReadOnly,
+		WriteOnlyPlaceholder: c.WriteOnlyPlaceholder,
+		Redaction:            c.Redaction,
+		EmptyArrays:          c.EmptyArrays,
+		ArrayMerge:           c.ArrayMerge,
+		ArrayMergeDocument:   c.ArrayMergeDocument,
+		OverrideSources:      c.OverrideSources,
+		ProvenanceComments:   c.ProvenanceComments,
+		skipPlaceholders:     c.skipPlaceholders,
+		EnumPlaceholder:      c.EnumPlaceholder,
+		ConstraintComments:   c.ConstraintComments,
+		LineLength:           c.LineLength,
+		Indent:               c.Indent,
+		PropertyOrder:        c.PropertyOrder,
+		MaxDepth:             c.MaxDepth,
+		path:                 c.path + "[" + strconv.Itoa(index) + "]",
+		visited:              c.visited,
+		origin:               c.origin,
+		overrideOrigins:      c.overrideOrigins,
 	}
 }
 
+// visit returns a copy of the config in which the given schema is marked as being rendered
+func (c *Config) visit(schema *jsonschema.Schema) *Config {
+	result := *c
+	result.visited = append(slices.Clip(c.visited), schema)
+
+	return &result
+}
+
+// recursion returns the number of times the given schema is already being rendered
+func (c *Config) recursion(schema *jsonschema.Schema) uint {
+	var result uint
+
+	for _, visited := range c.visited {
+		if visited == schema {
+			result++
+		}
+	}
+
+	return result
+}
+
+// joinPath appends the property name to the path using a dot as separator
+func joinPath(path string, propertyName string) string {
+	if path == "" {
+		return propertyName
+	}
+
+	return path + "." + propertyName
+}
+
 // overrideFor examines ValueOverrides to see if there are any override values defined for the given
 // propertyName.
 func (c *Config) overrideFor(propertyName string) (any, bool) {
@@ -182,6 +333,21 @@ func (c *Config) overrideFor(propertyName string) (any, bool) {
 	return propertyOverride, true
 }
 
+// currentValue returns the override value(s) that apply to the node the config was constructed for, which
+// is either a primitive override, the nested overrides of an object or the overrides of the items of an array.
+func (c *Config) currentValue() (any, bool) {
+	switch {
+	case c.HasOverride:
+		return c.ValueOverride, true
+	case len(c.ValueOverrides) > 0:
+		return c.ValueOverrides, true
+	case len(c.ItemsOverrides) > 0:
+		return c.ItemsOverrides, true
+	default:
+		return nil, false
+	}
+}
+
 // asSliceAny returns the input converted to []any, true if the input can be represented
 // as a []any (either directly or with reflect) or nil, false otherwise
 func asSliceAny(input any) ([]any, bool) {
@@ -250,6 +416,81 @@ func OnlyRequired() Option {
 	}
 }
 
+// WithEnumPlaceholder renders the first enum value of properties without a default value, instead of null. The
+// TODO comment is kept, as the value is only a suggestion.
+func WithEnumPlaceholder() Option {
+	return func(c *Config) {
+		c.EnumPlaceholder = true
+	}
+}
+
+// WithConstraintComments adds a line to the comment of every property that lists its validation constraints, such
+// as minimum/maximum, minLength/maxLength, pattern, format, minItems/maxItems and uniqueItems.
+func WithConstraintComments() Option {
+	return func(c *Config) {
+		c.ConstraintComments = true
+	}
+}
+
+// WithOptionalAsComments renders the required properties as usual, but the properties that are not required are
+// rendered as YAML that is commented out, including their default values and descriptions. This keeps the output
+// minimal while the optional properties can still be discovered.
+func WithOptionalAsComments() Option {
+	return func(c *Config) {
+		c.OptionalAsComments = true
+	}
+}
+
+// SkipDeprecated leaves out the properties that are marked as deprecated, unless an override value is given for them.
+// By default deprecated properties are rendered with a DEPRECATED comment.
+func SkipDeprecated() Option {
+	return func(c *Config) {
+		c.SkipDeprecated = true
+	}
+}
+
+// IncludeReadOnly renders the properties that are marked as readOnly, which are left out by default as they're not
+// meant to be configured by the user. Overridden readOnly properties are always rendered.
+func IncludeReadOnly() Option {
+	return func(c *Config) {
+		c.IncludeReadOnly = true
+	}
+}
+
+// IncludeWriteOnly renders the values of properties that are marked as writeOnly, which are replaced by a `<secret>`
+// placeholder by default as they're usually secrets such as passwords
+func IncludeWriteOnly() Option {
+	return func(c *Config) {
+		c.WriteOnlyPlaceholder = ""
+	}
+}
+
+// WithWriteOnlyPlaceholder renders the given placeholder instead of the default or override value of properties that
+// are marked as writeOnly, such as passwords, rather than the default `<secret>`. The override values are still
+// validated.
+func WithWriteOnlyPlaceholder(placeholder string) Option {
+	return func(c *Config) {
+		c.WriteOnlyPlaceholder = placeholder
+	}
+}
+
+// WithEmptyArrays renders arrays without override values or default value as an empty list (`[]`) instead of a list
+// with a single placeholder item. Arrays that require items through minItems, prefixItems or contains still render
+// placeholders for them.
+func WithEmptyArrays() Option {
+	return func(c *Config) {
+		c.EmptyArrays = true
+	}
+}
+
+// withoutPlaceholders leaves out the placeholder items of arrays without values, which document what the items
+// look like but would otherwise end up in the resolved values (see Unmarshal)
+func withoutPlaceholders() Option {
+	return func(c *Config) {
+		c.skipPlaceholders = true
+	}
+}
+
 // WithIndent amount of spaces to use when marshalling
 func WithIndent(indent int) Option {
 	return func(c *Config) {
@@ -272,6 +513,22 @@ func SkipValidate() Option {
 	}
 }
 
+// WithPropertyOrder determines the order of the keys of objects in the output, for example Alphabetical (the default),
+// RequiredFirst, SchemaOrder or a custom PropertyOrder.
+func WithPropertyOrder(order PropertyOrder) Option {
+	return func(c *Config) {
+		c.PropertyOrder = order
+	}
+}
+
+// WithMaxDepth sets the number of times a recursive schema may be nested when rendering override values, see
+// Config.MaxDepth. Defaults to 32.
+func WithMaxDepth(depth uint) Option {
+	return func(c *Config) {
+		c.MaxDepth = depth
+	}
+}
+
 // WithSchemaHeader will add the `# yaml-language-server: $schema=[...]` header to the output, allowing
 // IDEs to provide autocompletion.
 func WithSchemaHeader(schemaPath string) Option {
@@ -22,6 +22,7 @@ func TestConfig_ForProperty_ReturnsExpectedConfig(t *testing.T) {
 				TODOComment:  "abc",
 				LineLength:   20,
 				OnlyRequired: true,
+				MaxDepth:     5,
 			},
 			propertyName: "foo",
 
@@ -34,6 +35,8 @@ func TestConfig_ForProperty_ReturnsExpectedConfig(t *testing.T) {
 				TODOComment:       "abc",
 				OnlyRequired:      true,
 				LineLength:        20,
+				MaxDepth:          5,
+				path:              "foo",
 			},
 		},
 		"non-existing property returns empty ValueOverrides": {
@@ -49,6 +52,7 @@ func TestConfig_ForProperty_ReturnsExpectedConfig(t *testing.T) {
 				TODOComment:       "",
 				OnlyRequired:      false,
 				LineLength:        0,
+				path:              "does-not-exist",
 			},
 		},
 		"property that is not a map[string]any returns empty ValueOverrides": {
@@ -66,6 +70,7 @@ func TestConfig_ForProperty_ReturnsExpectedConfig(t *testing.T) {
 				TODOComment:       "",
 				OnlyRequired:      false,
 				LineLength:        0,
+				path:              "wrong-type",
 			},
 		},
 		"subproperty is returned as expected": {
@@ -83,6 +88,7 @@ func TestConfig_ForProperty_ReturnsExpectedConfig(t *testing.T) {
 				TODOComment:       "",
 				OnlyRequired:      false,
 				LineLength:        0,
+				path:              "foo",
 			},
 		},
 		"subproperty is returned with OnlyRequired=true if set on parent": {
@@ -100,6 +106,7 @@ func TestConfig_ForProperty_ReturnsExpectedConfig(t *testing.T) {
 				TODOComment:       "",
 				OnlyRequired:      true,
 				LineLength:        0,
+				path:              "foo",
 			},
 		},
 		"items overrides returned if input is a slice": {
@@ -120,6 +127,7 @@ func TestConfig_ForProperty_ReturnsExpectedConfig(t *testing.T) {
 				TODOComment:       "",
 				OnlyRequired:      true,
 				LineLength:        0,
+				path:              "beverages",
 			},
 		},
 	}
@@ -220,3 +228,51 @@ func TestConfig_forProperty_NilOverride(t *testing.T) {
 	require.NotNil(t, result)
 	assert.Equal(t, map[string]any{}, result.ValueOverrides)
 }
+
+func TestConfig_CurrentValue_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input *Config
+
+		expected    any
+		expectedHas bool
+	}{
+		"no overrides": {
+			input: NewConfig(),
+
+			expected:    nil,
+			expectedHas: false,
+		},
+		"primitive override": {
+			input: &Config{ValueOverride: nil, HasOverride: true},
+
+			expected:    nil,
+			expectedHas: true,
+		},
+		"object overrides": {
+			input: &Config{ValueOverrides: map[string]any{"foo": "bar"}},
+
+			expected:    map[string]any{"foo": "bar"},
+			expectedHas: true,
+		},
+		"items overrides": {
+			input: &Config{ItemsOverrides: []any{"foo"}},
+
+			expected:    []any{"foo"},
+			expectedHas: true,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := testData.input.currentValue()
+
+			// Assert
+			assert.Equal(t, testData.expectedHas, ok)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
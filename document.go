@@ -0,0 +1,170 @@
+package scheyaml
+
+import (
+	"fmt"
+
+	"github.com/kaptinlin/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaDocument links a compiled jsonschema.Schema to the raw document it was compiled from. The document
+// provides information that is not retained by the compiled schema, such as the order in which properties are
+// written and extension keywords like `x-order`.
+type SchemaDocument struct {
+	// nodes contains the raw mapping node of every (sub)schema in the document
+	nodes map[*jsonschema.Schema]*yaml.Node
+
+	// positions contains the position of every (sub)schema in the document, in order of appearance
+	positions map[*jsonschema.Schema]int
+}
+
+// NewSchemaDocument parses the raw JSON (or YAML) document the schema was compiled from. The document must be
+// the same one that was given to the jsonschema.Compiler, otherwise subschemas are not found.
+func NewSchemaDocument(schema *jsonschema.Schema, document []byte) (*SchemaDocument, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema is nil: %w", ErrInvalidInput)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(document, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse schema document: %w", err)
+	}
+
+	result := &SchemaDocument{
+		nodes:     make(map[*jsonschema.Schema]*yaml.Node),
+		positions: make(map[*jsonschema.Schema]int),
+	}
+
+	result.index(&root, schema)
+
+	return result, nil
+}
+
+// keyword returns the raw value of the given keyword of the schema, or nil, false if the schema does not
+// define it or is not part of the document
+func (d *SchemaDocument) keyword(schema *jsonschema.Schema, keyword string) (*yaml.Node, bool) {
+	if d == nil || schema == nil {
+		return nil, false
+	}
+
+	node, ok := d.nodes[schema]
+	if !ok {
+		return nil, false
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == keyword {
+			return node.Content[i+1], true
+		}
+	}
+
+	return nil, false
+}
+
+// position returns the position of the schema in the document. Schemas that were combined using allOf
+// are looked up by their first member.
+func (d *SchemaDocument) position(schema *jsonschema.Schema) (int, bool) {
+	for schema != nil {
+		if position, ok := d.positions[schema]; ok {
+			return position, true
+		}
+
+		if len(schema.AllOf) == 0 {
+			break
+		}
+
+		schema = schema.AllOf[0]
+	}
+
+	return 0, false
+}
+
+// index walks the raw node and the compiled schema side by side, recording the node and position of every
+// subschema in the order they appear in the document
+func (d *SchemaDocument) index(node *yaml.Node, schema *jsonschema.Schema) { //nolint:cyclop // a case per keyword
+	if node == nil || schema == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			d.index(node.Content[0], schema)
+		}
+
+		return
+	case yaml.AliasNode:
+		d.index(node.Alias, schema)
+
+		return
+	case yaml.MappingNode:
+	default:
+		return // e.g. boolean schemas
+	}
+
+	d.nodes[schema] = node
+	d.positions[schema] = len(d.positions)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+
+		switch node.Content[i].Value {
+		case "properties":
+			d.indexMap(value, schema.Properties)
+		case "patternProperties":
+			d.indexMap(value, schema.PatternProperties)
+		case "$defs":
+			d.indexMap(value, (*jsonschema.SchemaMap)(&schema.Defs))
+		case "dependentSchemas":
+			d.indexMap(value, (*jsonschema.SchemaMap)(&schema.DependentSchemas))
+		case "allOf":
+			d.indexSlice(value, schema.AllOf)
+		case "anyOf":
+			d.indexSlice(value, schema.AnyOf)
+		case "oneOf":
+			d.indexSlice(value, schema.OneOf)
+		case "prefixItems":
+			d.indexSlice(value, schema.PrefixItems)
+		case "items":
+			d.index(value, schema.Items)
+		case "contains":
+			d.index(value, schema.Contains)
+		case "additionalProperties":
+			d.index(value, schema.AdditionalProperties)
+		case "propertyNames":
+			d.index(value, schema.PropertyNames)
+		case "not":
+			d.index(value, schema.Not)
+		case "if":
+			d.index(value, schema.If)
+		case "then":
+			d.index(value, schema.Then)
+		case "else":
+			d.index(value, schema.Else)
+		}
+	}
+}
+
+// indexMap indexes the schemas of a keyword such as properties, in which the keys are names
+func (d *SchemaDocument) indexMap(node *yaml.Node, schemas *jsonschema.SchemaMap) {
+	if node == nil || schemas == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		d.index(node.Content[i+1], (*schemas)[node.Content[i].Value])
+	}
+}
+
+// indexSlice indexes the schemas of a keyword such as allOf, in which the schemas are listed
+func (d *SchemaDocument) indexSlice(node *yaml.Node, schemas []*jsonschema.Schema) {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for i, item := range node.Content {
+		if i < len(schemas) {
+			d.index(item, schemas[i])
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package scheyaml
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSchemaDocument_ReturnsErrorOnNilSchema(t *testing.T) {
+	t.Parallel()
+	// Act
+	result, err := NewSchemaDocument(nil, []byte(`{}`))
+
+	// Assert
+	require.ErrorIs(t, err, ErrInvalidInput)
+	assert.Nil(t, result)
+}
+
+func TestNewSchemaDocument_ReturnsErrorOnInvalidDocument(t *testing.T) {
+	t.Parallel()
+	// Act
+	result, err := NewSchemaDocument(&jsonschema.Schema{}, []byte(`{"type": `))
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestSchemaDocument_IndexesSubschemas(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := []byte(`{
+  "type": "object",
+  "properties": {
+    "b": {"$ref": "#/$defs/B", "x-order": 2},
+    "a": {"type": "array", "items": {"type": "string", "x-custom": "items"}}
+  },
+  "$defs": {
+    "B": {"type": "string", "x-custom": "def"}
+  }
+}`)
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile(inputData)
+	require.NoError(t, err)
+
+	// Act
+	result, err := NewSchemaDocument(schema, inputData)
+
+	// Assert
+	require.NoError(t, err)
+
+	b := (*schema.Properties)["b"]
+	a := (*schema.Properties)["a"]
+
+	order, ok := result.keyword(b, "x-order")
+	require.True(t, ok)
+	assert.Equal(t, "2", order.Value)
+
+	items, ok := result.keyword(a.Items, "x-custom")
+	require.True(t, ok)
+	assert.Equal(t, "items", items.Value)
+
+	def, ok := result.keyword(schema.Defs["B"], "x-custom")
+	require.True(t, ok)
+	assert.Equal(t, "def", def.Value)
+
+	_, ok = result.keyword(a, "x-custom")
+	assert.False(t, ok)
+
+	positionA, _ := result.position(a)
+	positionB, _ := result.position(b)
+	assert.Less(t, positionB, positionA)
+}
@@ -0,0 +1,194 @@
+package scheyaml
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kaptinlin/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// FillYAML takes an existing YAML document and adds the properties of the schema that are missing from it, including
+// their default values and comments. The values, comments, anchors and order of the keys that already exist in the
+// document are left untouched, new keys are added after the existing ones.
+//
+// The values in the document take precedence over the values given in WithOverrideValues.
+func FillYAML(schema *jsonschema.Schema, existing []byte, opts ...Option) ([]byte, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema is nil: %w", ErrInvalidInput)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(existing, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse existing document: %w", err)
+	}
+
+	result, err := FillNode(schema, &document, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fill document: %w", err)
+	}
+
+	config := NewConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	writer := new(bytes.Buffer)
+
+	encoder := yaml.NewEncoder(writer)
+	if config.Indent != 0 {
+		encoder.SetIndent(config.Indent)
+	}
+
+	if encodeErr := encoder.Encode(result); encodeErr != nil {
+		return nil, fmt.Errorf("failed to marshal yaml nodes: %w", encodeErr)
+	}
+
+	return writer.Bytes(), nil
+}
+
+// FillNode is a lower-level version of FillYAML, it adds the missing properties to the given node (which is modified
+// in place) and returns it. If the node is empty, the result of SchemaToNode is returned.
+func FillNode(schema *jsonschema.Schema, existing *yaml.Node, opts ...Option) (*yaml.Node, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema is nil: %w", ErrInvalidInput)
+	}
+
+	if existing == nil || existing.Kind == 0 || (existing.Kind == yaml.DocumentNode && len(existing.Content) == 0) {
+		return SchemaToNode(schema, opts...)
+	}
+
+	root := existing
+	if root.Kind == yaml.DocumentNode {
+		root = root.Content[0]
+	}
+
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("existing document is not a mapping: %w", ErrInvalidInput)
+	}
+
+	var values map[string]any
+	if err := root.Decode(&values); err != nil {
+		return nil, fmt.Errorf("failed to decode existing document: %w", err)
+	}
+
+	// the options of the caller are copied, as appending to them could overwrite their backing array
+	generated, err := SchemaToNode(schema, slices.Concat(opts, []Option{func(c *Config) {
+		c.ValueOverrides = mergeValues(c.ValueOverrides, values)
+	}})...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the header is only added if the document doesn't have a head comment of its own
+	if root.HeadComment == "" && existing.HeadComment == "" {
+		root.HeadComment = generated.HeadComment
+	}
+
+	fillNode(root, generated)
+	untagMergeKeys(existing)
+
+	return existing, nil
+}
+
+// untagMergeKeys removes the `!!merge` tag that yaml.v3 sets on merge keys when decoding, as it would otherwise be
+// written out explicitly (`!!merge <<: *base`). Merge keys without a tag are still recognised when decoding.
+func untagMergeKeys(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if key := node.Content[i]; key.Kind == yaml.ScalarNode && key.Value == "<<" && key.Tag == mergeTag {
+				key.Tag = ""
+			}
+		}
+	}
+
+	// aliases are not followed, the anchored node is visited where it's defined
+	for _, child := range node.Content {
+		untagMergeKeys(child)
+	}
+}
+
+// fillNode adds the keys of generated that are missing in target, it descends into mappings and sequences that
+// exist in both. Aliases are not followed, as the anchored node is shared with other parts of the document.
+func fillNode(target *yaml.Node, generated *yaml.Node) {
+	if target == nil || generated == nil || target.Kind != generated.Kind {
+		return
+	}
+
+	switch target.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(generated.Content); i += 2 {
+			key, value := generated.Content[i], generated.Content[i+1]
+
+			if existing, ok := mappingValue(target, key.Value); ok {
+				fillNode(existing, value)
+				continue
+			}
+
+			target.Content = append(target.Content, key, value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range target.Content {
+			if i < len(generated.Content) {
+				fillNode(item, generated.Content[i])
+			}
+		}
+	default:
+		return
+	}
+}
+
+// mappingValue returns the value of the given key in the mapping node, or nil, false if the key does not exist.
+// Keys that are provided by a merge key (`<<: *anchor`) exist, but return a nil value as the anchored node is
+// left untouched.
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, bool) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1], true
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "<<" {
+			continue
+		}
+
+		merged := []*yaml.Node{node.Content[i+1]}
+		if node.Content[i+1].Kind == yaml.SequenceNode {
+			merged = node.Content[i+1].Content
+		}
+
+		for _, alias := range merged {
+			if alias.Kind == yaml.AliasNode && alias.Alias != nil && alias.Alias.Kind == yaml.MappingNode {
+				if _, ok := mappingValue(alias.Alias, key); ok {
+					return nil, true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// mergeValues returns a new map with the values of overlay deeply merged onto the values of base, nested maps are
+// merged while any other value in overlay replaces the value in base.
+func mergeValues(base map[string]any, overlay map[string]any) map[string]any {
+	result := make(map[string]any, len(base)+len(overlay))
+	maps.Copy(result, base)
+
+	for key, value := range overlay {
+		baseMap, baseIsMap := asMapStringAny(result[key])
+		overlayMap, overlayIsMap := asMapStringAny(value)
+
+		if baseIsMap && overlayIsMap {
+			result[key] = mergeValues(baseMap, overlayMap)
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
@@ -0,0 +1,205 @@
+package scheyaml
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFillYAML_AddsMissingPropertiesAndKeepsExistingDocument(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "default": "Robin", "description": "The name of the customer"},
+    "port": {"type": "integer", "default": 8080},
+    "database": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost"},
+        "user": {"type": "string", "description": "The user to log in with"}
+      }
+    },
+    "replica": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost"},
+        "user": {"type": "string"}
+      }
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	existing := `# My configuration
+port: 9090 # not the default
+database: &database
+    # Overwritten by hand
+    host: db.example.com
+replica: *database
+`
+
+	// Act
+	result, err := FillYAML(schema, []byte(existing), WithIndent(4))
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# My configuration
+port: 9090 # not the default
+database: &database
+    # Overwritten by hand
+    host: db.example.com
+    # The user to log in with
+    user: null # TODO: Fill this in
+replica: *database
+# The name of the customer
+name: Robin
+`
+
+	assert.Equal(t, expectedData, string(result))
+}
+
+func TestFillYAML_DoesNotDuplicateMergedKeys(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "base": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost"}
+      }
+    },
+    "server": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost"},
+        "port": {"type": "integer", "default": 80}
+      }
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	existing := `base: &base
+    host: example.com
+server:
+    <<: *base
+`
+
+	// Act
+	result, err := FillYAML(schema, []byte(existing), WithIndent(4))
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `base: &base
+    host: example.com
+server:
+    <<: *base
+    port: 80
+`
+
+	assert.Equal(t, expectedData, string(result))
+}
+
+func TestFillYAML_ReturnsGeneratedDocumentOnEmptyInput(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{"type": "object", "properties": {"name": {"type": "string", "default": "Robin"}}}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	// Act
+	result, err := FillYAML(schema, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "name: Robin\n", string(result))
+}
+
+func TestFillNode_ReturnsErrorOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(`{"type": "object"}`))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		schema   *jsonschema.Schema
+		existing *yaml.Node
+	}{
+		"nil schema": {
+			schema:   nil,
+			existing: &yaml.Node{Kind: yaml.MappingNode},
+		},
+		"not a mapping": {
+			schema:   schema,
+			existing: &yaml.Node{Kind: yaml.ScalarNode, Value: "abc"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := FillNode(testData.schema, testData.existing)
+
+			// Assert
+			assert.Nil(t, result)
+			require.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestFillNode_LeavesOptionsOfCallerUntouched(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(`{"type": "object", "properties": {"name": {"type": "string"}}}`))
+	require.NoError(t, err)
+
+	var document yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("name: Robin\n"), &document))
+
+	// the spare capacity of the slice must not be written to
+	opts := make([]Option, 1, 2)
+	opts[0] = WithTODOComment("")
+	spare := opts[:2]
+
+	// Act
+	_, err = FillNode(schema, &document, opts...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, spare[1])
+}
+
+func TestMergeValues_ReturnsExpectedResult(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	base := map[string]any{"a": 1, "nested": map[string]any{"b": 2, "c": 3}}
+	overlay := map[string]any{"nested": map[string]any{"c": 4}, "d": 5}
+
+	// Act
+	result := mergeValues(base, overlay)
+
+	// Assert
+	expected := map[string]any{"a": 1, "nested": map[string]any{"b": 2, "c": 4}, "d": 5}
+	assert.Equal(t, expected, result)
+	assert.Equal(t, map[string]any{"b": 2, "c": 3}, base["nested"])
+}
@@ -7,9 +7,9 @@ import (
 	"github.com/kaptinlin/jsonschema"
 )
 
-// nullable iff the schema is not nil, has only two types where the second type is 'null'
+// nullable iff the schema is not nil and 'null' is one of its types
 func nullable(schema *jsonschema.Schema) bool {
-	return schema != nil && len(schema.Type) == 2 && schema.Type[1] == "null"
+	return schema != nil && slices.Contains(schema.Type, NullValue)
 }
 
 // required returns true iff the propertyName is contained in the required property slice
@@ -27,11 +27,76 @@ func withDescription(schema *jsonschema.Schema) bool {
 	return schema != nil && schema.Description != nil && *schema.Description != ""
 }
 
+// withTitle returns the first schema that is not nil and for which the title is non-empty
+func withTitle(schema *jsonschema.Schema) bool {
+	return schema != nil && schema.Title != nil && *schema.Title != ""
+}
+
 // withExamples returns the first schema which has examples
 func withExamples(schema *jsonschema.Schema) bool {
 	return schema != nil && len(schema.Examples) > 0
 }
 
+// withEnum returns the first schema which has enum values
+func withEnum(schema *jsonschema.Schema) bool {
+	return schema != nil && len(schema.Enum) > 0
+}
+
+// withConst returns the first schema which has a const value
+func withConst(schema *jsonschema.Schema) bool {
+	return schema != nil && schema.Const != nil && schema.Const.IsSet
+}
+
+// flagged returns the value of the boolean keyword (e.g. deprecated) of the first schema that defines it, or false if
+// none of the schemas define it
+func flagged(schemas []*jsonschema.Schema, keyword func(*jsonschema.Schema) *bool) bool {
+	for _, schema := range schemas {
+		if schema == nil {
+			continue
+		}
+
+		if value := keyword(schema); value != nil {
+			return *value
+		}
+	}
+
+	return false
+}
+
+// deprecated returns the deprecated keyword of the schema
+func deprecated(schema *jsonschema.Schema) *bool {
+	return schema.Deprecated
+}
+
+// readOnly returns the readOnly keyword of the schema
+func readOnly(schema *jsonschema.Schema) *bool {
+	return schema.ReadOnly
+}
+
+// writeOnly returns the writeOnly keyword of the schema
+func writeOnly(schema *jsonschema.Schema) *bool {
+	return schema.WriteOnly
+}
+
+// additionalSchema returns the additionalProperties schema of the object schema, boolean schemas such as
+// `additionalProperties: false` are not returned as they don't describe the properties
+func additionalSchema(schema *jsonschema.Schema) (*jsonschema.Schema, bool) {
+	if schema == nil || schema.AdditionalProperties == nil || schema.AdditionalProperties.Boolean != nil {
+		return nil, false
+	}
+
+	return schema.AdditionalProperties, true
+}
+
+// derefString returns the value of the string pointer, or an empty string if it is nil
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
 // notNil returns true if a received pointer to some element E is not nil
 func notNil[E any](element *E) bool {
 	return element != nil
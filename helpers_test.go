@@ -3,6 +3,7 @@ package scheyaml
 import (
 	"testing"
 
+	"github.com/kaptinlin/jsonschema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -48,3 +49,50 @@ func TestAll_Success(t *testing.T) {
 	// Assert
 	assert.True(t, matches)
 }
+
+func TestNullable_ReturnsExpectedResult(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input *jsonschema.Schema
+
+		expected bool
+	}{
+		"nil schema": {
+			input: nil,
+
+			expected: false,
+		},
+		"single type": {
+			input: &jsonschema.Schema{Type: jsonschema.SchemaType{"string"}},
+
+			expected: false,
+		},
+		"null last": {
+			input: &jsonschema.Schema{Type: jsonschema.SchemaType{"string", "null"}},
+
+			expected: true,
+		},
+		"null first": {
+			input: &jsonschema.Schema{Type: jsonschema.SchemaType{"null", "string"}},
+
+			expected: true,
+		},
+		"null in between": {
+			input: &jsonschema.Schema{Type: jsonschema.SchemaType{"integer", "null", "string"}},
+
+			expected: true,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := nullable(testData.input)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
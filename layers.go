@@ -0,0 +1,70 @@
+package scheyaml
+
+import (
+	"maps"
+	"strings"
+)
+
+// MergeLayers deeply merges the layers of override values in order, so the values of a layer take precedence over
+// the values of the layers before it. Objects are merged while any other value, including arrays, SkipValue and
+// NullValue, replaces the value of the previous layers.
+//
+// Besides the merged values, the index of the layer that each value came from is returned by path (e.g. `db.port`, see
+// WithOverridePaths). Objects are recorded as well, with the index of the last layer that contained them.
+func MergeLayers(layers ...map[string]any) (map[string]any, map[string]int) {
+	result := make(map[string]any)
+	sources := make(map[string]int)
+
+	for index, layer := range layers {
+		result = mergeLayer(result, layer, index, "", sources)
+	}
+
+	return result, sources
+}
+
+// mergeLayer returns a copy of target with the values of the layer deeply merged onto it, the sources of the values
+// that are set are recorded with the index of the layer
+func mergeLayer(target map[string]any, layer map[string]any, index int, path string, sources map[string]int) map[string]any {
+	result := make(map[string]any, len(target)+len(layer))
+	maps.Copy(result, target)
+
+	for key, value := range layer {
+		keyPath := joinPath(path, key)
+		sources[keyPath] = index
+
+		if overlay, isMap := asMapStringAny(value); isMap {
+			existing, existingIsMap := asMapStringAny(result[key])
+			if !existingIsMap {
+				forgetSources(sources, keyPath)
+			}
+
+			result[key] = mergeLayer(existing, overlay, index, keyPath, sources)
+
+			continue
+		}
+
+		// the nested values of previous layers are replaced entirely
+		forgetSources(sources, keyPath)
+
+		result[key] = cloneValue(value)
+	}
+
+	return result
+}
+
+// forgetSources removes the sources of the values nested in the given path
+func forgetSources(sources map[string]int, path string) {
+	maps.DeleteFunc(sources, func(source string, _ int) bool {
+		return strings.HasPrefix(source, path+".")
+	})
+}
+
+// WithOverrideLayers deeply merges the layers of override values in order (see MergeLayers) and uses the result as
+// override values, replacing the values of WithOverrideValues. For example a base file, an environment specific file
+// and command line flags. SkipValue and NullValue are respected per layer, so a later layer can restore a key that an
+// earlier layer skipped. The layer each value came from is recorded in Config.OverrideSources.
+func WithOverrideLayers(layers ...map[string]any) Option {
+	return func(c *Config) {
+		c.ValueOverrides, c.OverrideSources = MergeLayers(layers...)
+	}
+}
@@ -0,0 +1,118 @@
+package scheyaml
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeLayers_ReturnsExpectedValuesAndSources(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		layers []map[string]any
+
+		expectedValues  map[string]any
+		expectedSources map[string]int
+	}{
+		"no layers": {
+			expectedValues:  map[string]any{},
+			expectedSources: map[string]int{},
+		},
+		"objects are merged": {
+			layers: []map[string]any{
+				{"db": map[string]any{"host": "localhost", "port": 5432}, "name": "base"},
+				nil,
+				{"db": map[string]any{"port": 6432}},
+			},
+
+			expectedValues: map[string]any{"db": map[string]any{"host": "localhost", "port": 6432}, "name": "base"},
+			expectedSources: map[string]int{
+				"db":      2,
+				"db.host": 0,
+				"db.port": 2,
+				"name":    0,
+			},
+		},
+		"arrays and sentinels replace previous values": {
+			layers: []map[string]any{
+				{"db": map[string]any{"host": "localhost"}, "tags": []any{"a", "b"}, "name": "base"},
+				{"db": NullValue, "tags": []any{"c"}, "name": SkipValue},
+			},
+
+			expectedValues:  map[string]any{"db": NullValue, "tags": []any{"c"}, "name": SkipValue},
+			expectedSources: map[string]int{"db": 1, "tags": 1, "name": 1},
+		},
+		"later layers restore skipped values": {
+			layers: []map[string]any{
+				{"db": SkipValue},
+				{"db": map[string]any{"host": "db.example.com"}},
+			},
+
+			expectedValues:  map[string]any{"db": map[string]any{"host": "db.example.com"}},
+			expectedSources: map[string]int{"db": 1, "db.host": 1},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			values, sources := MergeLayers(testData.layers...)
+
+			// Assert
+			assert.Equal(t, testData.expectedValues, values)
+			assert.Equal(t, testData.expectedSources, sources)
+		})
+	}
+}
+
+func TestMergeLayers_LeavesLayersUntouched(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	base := map[string]any{"db": map[string]any{"host": "localhost"}}
+
+	// Act
+	_, _ = MergeLayers(base, map[string]any{"db": map[string]any{"host": "db.example.com"}})
+
+	// Assert
+	assert.Equal(t, map[string]any{"db": map[string]any{"host": "localhost"}}, base)
+}
+
+func TestWithOverrideLayers_AppliesMergedLayers(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema, err := jsonschema.NewCompiler().Compile([]byte(`{
+  "type": "object",
+  "properties": {
+    "debug": {"type": "boolean", "default": false},
+    "db": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost"},
+        "port": {"type": "integer", "default": 5432}
+      }
+    }
+  }
+}`))
+	require.NoError(t, err)
+
+	defaults := map[string]any{"debug": true}
+	base := map[string]any{"db": map[string]any{"host": "db.example.com"}}
+	environment := map[string]any{"db": map[string]any{"port": 6432}, "debug": SkipValue}
+
+	// Act
+	result, err := SchemaToYAML(schema, WithOverrideLayers(defaults, base, environment))
+
+	// Assert
+	require.NoError(t, err)
+
+	expected := `db:
+    host: db.example.com
+    port: 6432
+`
+
+	assert.Equal(t, expected, string(result))
+}
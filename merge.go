@@ -0,0 +1,144 @@
+package scheyaml
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+// ArrayMergeStrategy determines how the override items of an array are combined with the items of its default value,
+// see WithArrayMerge
+type ArrayMergeStrategy int
+
+const (
+	// ReplaceItems replaces the default items with the override items, this is the default
+	ReplaceItems ArrayMergeStrategy = iota
+
+	// MergeItemsByIndex merges every override item onto the default item at the same index, default items without an
+	// override are kept and override items beyond the default items are added
+	MergeItemsByIndex
+
+	// MergeItemsByKey merges every override item onto the default item with the same value for the key given by the
+	// `x-merge-key` extension of the array schema, override items without a match are added. Arrays without the
+	// extension are replaced.
+	MergeItemsByKey
+)
+
+// mergeKeyword is the extension keyword of an array schema that contains the key used by MergeItemsByKey
+const mergeKeyword = "x-merge-key"
+
+// mergeItems combines the default items of the array with the override items according to the strategy of the config,
+// schemas are the (raw) schemas of the array that are searched for the merge key. The values that come from the default
+// items are labelled for WithProvenanceComments.
+func (c *Config) mergeItems(schemas []*jsonschema.Schema, defaults []any, overrides []any) []any {
+	var (
+		result     []any
+		overridden []int
+	)
+
+	switch c.ArrayMerge {
+	case MergeItemsByIndex:
+		result, overridden = mergeItemsByIndex(defaults, overrides)
+	case MergeItemsByKey:
+		key := c.mergeKey(schemas)
+		if key == "" {
+			return overrides
+		}
+
+		result, overridden = mergeItemsByKey(defaults, overrides, key)
+	default:
+		return overrides
+	}
+
+	c.labelMergedItems(result, overridden, overrides)
+
+	return result
+}
+
+// mergeKey returns the key of the `x-merge-key` extension of the first schema that has one, or an empty string
+func (c *Config) mergeKey(schemas []*jsonschema.Schema) string {
+	for _, schema := range schemas {
+		if node, ok := c.ArrayMergeDocument.keyword(schema, mergeKeyword); ok && node.Value != "" {
+			return node.Value
+		}
+	}
+
+	return ""
+}
+
+// mergeItemsByIndex merges the override items onto the default items with the same index, objects are merged deeply
+// and any other override item replaces the default item. Besides the items, the index of the override item that was
+// merged into every item is returned, or -1 for default items without an override.
+func mergeItemsByIndex(defaults []any, overrides []any) ([]any, []int) {
+	result, _ := cloneValue(defaults).([]any)
+	overridden := slices.Repeat([]int{-1}, len(result))
+
+	for i, override := range overrides {
+		if i >= len(result) {
+			result = append(result, override)
+			overridden = append(overridden, i)
+
+			continue
+		}
+
+		result[i] = mergeItem(result[i], override)
+		overridden[i] = i
+	}
+
+	return result, overridden
+}
+
+// mergeItemsByKey merges the override items onto the default items with the same value for the key, the values are
+// compared by their string representation as numbers may be decoded as different types. The index of the override
+// item that was merged into every item is returned as well, like mergeItemsByIndex.
+func mergeItemsByKey(defaults []any, overrides []any, key string) ([]any, []int) {
+	result, _ := cloneValue(defaults).([]any)
+	overridden := slices.Repeat([]int{-1}, len(result))
+
+	for i, override := range overrides {
+		overrideValues, isMap := asMapStringAny(override)
+		overrideKey, hasKey := overrideValues[key]
+
+		index := slices.IndexFunc(result, func(item any) bool {
+			values, isItemMap := asMapStringAny(item)
+			itemKey, hasItemKey := values[key]
+
+			return isItemMap && hasItemKey && fmt.Sprint(itemKey) == fmt.Sprint(overrideKey)
+		})
+
+		if !isMap || !hasKey || index < 0 {
+			result = append(result, override)
+			overridden = append(overridden, i)
+
+			continue
+		}
+
+		result[index] = mergeItem(result[index], override)
+		overridden[index] = i
+	}
+
+	return result, overridden
+}
+
+// mergeItem merges the override item onto the default item if both are objects, otherwise the override is returned
+func mergeItem(item any, override any) any {
+	itemValues, itemIsMap := asMapStringAny(item)
+	overrideValues, overrideIsMap := asMapStringAny(override)
+
+	if itemIsMap && overrideIsMap {
+		return mergeValues(itemValues, overrideValues)
+	}
+
+	return override
+}
+
+// WithArrayMerge determines how the override items of an array are combined with the items of its default value, for
+// example to change a single server in a list of servers without repeating the others. MergeItemsByKey reads the
+// `x-merge-key` extension of arrays from the document, which may be nil for the other strategies.
+func WithArrayMerge(strategy ArrayMergeStrategy, document *SchemaDocument) Option {
+	return func(c *Config) {
+		c.ArrayMerge = strategy
+		c.ArrayMergeDocument = document
+	}
+}
@@ -0,0 +1,150 @@
+package scheyaml
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeItems_ReturnsExpectedItems(t *testing.T) {
+	t.Parallel()
+
+	defaults := []any{
+		map[string]any{"name": "api", "port": 80, "tls": map[string]any{"enabled": false}},
+		map[string]any{"name": "worker", "port": 81},
+	}
+
+	tests := map[string]struct {
+		strategy  ArrayMergeStrategy
+		overrides []any
+
+		expected []any
+	}{
+		"replace": {
+			strategy:  ReplaceItems,
+			overrides: []any{map[string]any{"name": "worker", "port": 90}},
+
+			expected: []any{map[string]any{"name": "worker", "port": 90}},
+		},
+		"by index": {
+			strategy: MergeItemsByIndex,
+			overrides: []any{
+				map[string]any{"tls": map[string]any{"enabled": true}},
+				"worker",
+				map[string]any{"name": "cron"},
+			},
+
+			expected: []any{
+				map[string]any{"name": "api", "port": 80, "tls": map[string]any{"enabled": true}},
+				"worker",
+				map[string]any{"name": "cron"},
+			},
+		},
+		"by key": {
+			strategy: MergeItemsByKey,
+			overrides: []any{
+				map[string]any{"name": "worker", "port": 90},
+				map[string]any{"name": "cron"},
+				map[string]any{"port": 100},
+			},
+
+			expected: []any{
+				map[string]any{"name": "api", "port": 80, "tls": map[string]any{"enabled": false}},
+				map[string]any{"name": "worker", "port": 90},
+				map[string]any{"name": "cron"},
+				map[string]any{"port": 100},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			inputData := []byte(`{"type": "array", "x-merge-key": "name"}`)
+
+			schema, err := jsonschema.NewCompiler().Compile(inputData)
+			require.NoError(t, err)
+
+			document, err := NewSchemaDocument(schema, inputData)
+			require.NoError(t, err)
+
+			cfg := NewConfig()
+			WithArrayMerge(testData.strategy, document)(cfg)
+
+			// Act
+			result := cfg.mergeItems([]*jsonschema.Schema{schema}, defaults, testData.overrides)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+
+			// the defaults are left untouched
+			assert.Equal(t, map[string]any{"enabled": false}, defaults[0].(map[string]any)["tls"])
+		})
+	}
+}
+
+func TestMergeItems_ReplacesItemsWithoutMergeKey(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	cfg := NewConfig()
+	WithArrayMerge(MergeItemsByKey, nil)(cfg)
+
+	overrides := []any{map[string]any{"name": "worker"}}
+
+	// Act
+	result := cfg.mergeItems([]*jsonschema.Schema{{}}, []any{map[string]any{"name": "api"}}, overrides)
+
+	// Assert
+	assert.Equal(t, overrides, result)
+}
+
+func TestWithArrayMerge_PatchesDefaultItems(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := []byte(`{
+  "type": "object",
+  "properties": {
+    "servers": {
+      "type": "array",
+      "x-merge-key": "name",
+      "items": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "port": {"type": "integer"},
+          "replicas": {"type": "integer", "default": 1}
+        }
+      },
+      "default": [{"name": "api", "port": 80}, {"name": "worker", "port": 81}]
+    }
+  }
+}`)
+
+	schema, err := jsonschema.NewCompiler().Compile(inputData)
+	require.NoError(t, err)
+
+	document, err := NewSchemaDocument(schema, inputData)
+	require.NoError(t, err)
+
+	overrides := map[string]any{"servers": []any{map[string]any{"name": "worker", "replicas": 3}}}
+
+	// Act
+	result, err := SchemaToYAML(schema, WithOverrideValues(overrides), WithArrayMerge(MergeItemsByKey, document))
+
+	// Assert
+	require.NoError(t, err)
+
+	expected := `servers:
+    - name: api
+      port: 80
+      replicas: 1
+    - name: worker
+      port: 81
+      replicas: 3
+`
+
+	assert.Equal(t, expected, string(result))
+}
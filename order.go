@@ -0,0 +1,70 @@
+package scheyaml
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+// PropertyOrder compares two property names of the given object schema in the style of slices.SortFunc, returning
+// a negative number if a should be placed before b, a positive number if it should be placed after b or 0 if the
+// order doesn't matter. Properties that compare as equal are placed in alphabetical order.
+type PropertyOrder func(schema *jsonschema.Schema, a, b string) int
+
+// Alphabetical orders the properties by name, this is the default
+func Alphabetical(_ *jsonschema.Schema, a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// RequiredFirst places the required properties of an object before the optional ones
+func RequiredFirst(schema *jsonschema.Schema, a, b string) int {
+	return cmp.Compare(rankRequired(schema, a), rankRequired(schema, b))
+}
+
+// SchemaOrder places the properties in the order they are written in the schema document. Properties that define
+// an `x-order` or `propertyOrder` keyword are placed first, in ascending order of that keyword. Properties that are
+// not part of the document, like overrides matching a pattern property, are placed last.
+func SchemaOrder(document *SchemaDocument) PropertyOrder {
+	return func(schema *jsonschema.Schema, a, b string) int {
+		groupA, rankA := document.rankProperty(schema, a)
+		groupB, rankB := document.rankProperty(schema, b)
+
+		return cmp.Or(cmp.Compare(groupA, groupB), cmp.Compare(rankA, rankB))
+	}
+}
+
+// rankRequired returns 0 for required properties and 1 for optional properties
+func rankRequired(schema *jsonschema.Schema, propertyName string) int {
+	if schema != nil && required(schema, propertyName) {
+		return 0
+	}
+
+	return 1
+}
+
+// rankProperty returns the group and rank of the property within that group. Group 0 consists of properties with
+// an explicit order keyword, group 1 of properties ordered by their position in the document and group 2 of
+// properties that are not found in the document.
+func (d *SchemaDocument) rankProperty(schema *jsonschema.Schema, propertyName string) (int, float64) {
+	if d == nil || schema == nil || schema.Properties == nil {
+		return 2, 0 //nolint:mnd // group of unknown properties
+	}
+
+	property := (*schema.Properties)[propertyName]
+
+	for _, keyword := range []string{"x-order", "propertyOrder"} {
+		if node, ok := d.keyword(property, keyword); ok {
+			if order, err := strconv.ParseFloat(node.Value, 64); err == nil {
+				return 0, order
+			}
+		}
+	}
+
+	if position, ok := d.position(property); ok {
+		return 1, float64(position)
+	}
+
+	return 2, 0 //nolint:mnd // group of unknown properties
+}
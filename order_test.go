@@ -0,0 +1,75 @@
+package scheyaml
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyOrder_SortsAsExpected(t *testing.T) {
+	t.Parallel()
+
+	inputData := []byte(`{
+  "type": "object",
+  "required": ["name", "advanced"],
+  "properties": {
+    "name": {"type": "string"},
+    "description": {"type": "string"},
+    "advanced": {"type": "object"},
+    "id": {"type": "string", "x-order": 1}
+  }
+}`)
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile(inputData)
+	require.NoError(t, err)
+
+	document, err := NewSchemaDocument(schema, inputData)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		order PropertyOrder
+
+		expected []string
+	}{
+		"alphabetical": {
+			order: Alphabetical,
+
+			expected: []string{"advanced", "description", "id", "name", "unknown"},
+		},
+		"required first": {
+			order: RequiredFirst,
+
+			expected: []string{"advanced", "name", "description", "id", "unknown"},
+		},
+		"schema order": {
+			order: SchemaOrder(document),
+
+			expected: []string{"id", "name", "description", "advanced", "unknown"},
+		},
+		"schema order without document": {
+			order: SchemaOrder(nil),
+
+			expected: []string{"advanced", "description", "id", "name", "unknown"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			properties := []string{"advanced", "description", "id", "name", "unknown"}
+
+			// Act
+			slices.SortStableFunc(properties, func(a, b string) int {
+				return testData.order(schema, a, b)
+			})
+
+			// Assert
+			assert.Equal(t, testData.expected, properties)
+		})
+	}
+}
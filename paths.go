@@ -0,0 +1,290 @@
+package scheyaml
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kaptinlin/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// pathSegment is a single key or index of an override path such as `servers[1].host`
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath splits an override path into its segments, keys are separated by dots and indexes are written
+// between square brackets
+func parsePath(path string) ([]pathSegment, error) {
+	parts := strings.Split(path, ".")
+	result := make([]pathSegment, 0, len(parts))
+
+	for _, part := range parts {
+		key, rest, hasIndex := strings.Cut(part, "[")
+		if key == "" {
+			return nil, fmt.Errorf("path %q contains an empty key: %w", path, ErrInvalidInput)
+		}
+
+		result = append(result, pathSegment{key: key})
+
+		for hasIndex {
+			rawIndex, after, closed := strings.Cut(rest, "]")
+
+			index, err := strconv.Atoi(rawIndex)
+			if !closed || err != nil || index < 0 {
+				return nil, fmt.Errorf("path %q contains an invalid index: %w", path, ErrInvalidInput)
+			}
+
+			result = append(result, pathSegment{index: index, isIndex: true})
+
+			if rest, hasIndex = strings.CutPrefix(after, "["); !hasIndex && rest != "" {
+				return nil, fmt.Errorf("path %q contains an invalid index: %w", path, ErrInvalidInput)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// expandPaths returns a copy of the overrides with the values of the paths set in them. The paths are applied in
+// alphabetical order, so `a.b` is applied after `a`. String values are coerced to the type the schema declares
+// for the path.
+func expandPaths(schema *jsonschema.Schema, overrides map[string]any, paths map[string]any) (map[string]any, error) {
+	result, _ := cloneValue(overrides).(map[string]any)
+	if result == nil {
+		result = make(map[string]any)
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		segments, err := parsePath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		result, _ = setPath(result, schema, segments, paths[path]).(map[string]any)
+	}
+
+	return result, nil
+}
+
+// setPath sets the value at the path within current and returns the result, creating objects and arrays as needed
+func setPath(current any, schema *jsonschema.Schema, segments []pathSegment, value any) any {
+	schema = effectiveSchema(schema, NewConfig())
+
+	if len(segments) == 0 {
+		return coerceValue(schema, value)
+	}
+
+	segment := segments[0]
+
+	if index, isIndex := segment.asIndex(current, schema); isIndex {
+		items, _ := asSliceAny(current)
+		items = slices.Clone(items)
+
+		// items before the index are padded, with empty objects if the path continues with a key
+		for len(items) <= index {
+			var padding any
+			if len(segments) > 1 && !segments[1].isIndex {
+				padding = make(map[string]any)
+			}
+
+			items = append(items, padding)
+		}
+
+		items[index] = setPath(items[index], itemSchema(schema, index), segments[1:], value)
+
+		return items
+	}
+
+	values, isMap := asMapStringAny(current)
+	if !isMap {
+		values = make(map[string]any)
+	}
+
+	key, property := lookupProperty(schema, segment.key)
+	values[key] = setPath(values[key], property, segments[1:], value)
+
+	return values
+}
+
+// asIndex returns the index the segment refers to, a key is considered an index if it is numeric and the
+// current value or schema is an array
+func (s pathSegment) asIndex(current any, schema *jsonschema.Schema) (int, bool) {
+	if s.isIndex {
+		return s.index, true
+	}
+
+	index, err := strconv.Atoi(s.key)
+	if err != nil || index < 0 {
+		return 0, false
+	}
+
+	if _, isSlice := asSliceAny(current); isSlice {
+		return index, true
+	}
+
+	return index, schema != nil && slices.Contains(schema.Type, "array")
+}
+
+// lookupProperty returns the name and schema of the property of the object schema, properties are matched by exact
+// name first and case-insensitively second, so keys derived from environment variables are found as well. If no
+// property matches, the pattern properties are used and finally the additionalProperties schema.
+func lookupProperty(schema *jsonschema.Schema, key string) (string, *jsonschema.Schema) {
+	if schema == nil {
+		return key, nil
+	}
+
+	if schema.Properties != nil {
+		if property, ok := (*schema.Properties)[key]; ok {
+			return key, property
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(*schema.Properties)) {
+			if strings.EqualFold(name, key) {
+				return name, (*schema.Properties)[name]
+			}
+		}
+	}
+
+	if schema.PatternProperties != nil {
+		for _, pattern := range slices.Sorted(maps.Keys(*schema.PatternProperties)) {
+			if matched, err := regexp.MatchString(pattern, key); err == nil && matched {
+				return key, (*schema.PatternProperties)[pattern]
+			}
+		}
+	}
+
+	if additional, ok := additionalSchema(schema); ok {
+		return key, additional
+	}
+
+	return key, nil
+}
+
+// itemSchema returns the schema of the item at the index of the array schema, or nil if it's not described by a
+// schema (e.g. `items: false`)
+func itemSchema(schema *jsonschema.Schema, index int) *jsonschema.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	result := schema.Items
+	if index < len(schema.PrefixItems) {
+		result = schema.PrefixItems[index]
+	}
+
+	if result == nil || result.Boolean != nil {
+		return nil
+	}
+
+	return result
+}
+
+// coerceValue converts a string value to the first type of the schema it can be parsed as, other values and strings
+// that can't be converted are returned as-is
+func coerceValue(schema *jsonschema.Schema, value any) any {
+	raw, isString := value.(string)
+	if !isString || schema == nil {
+		return value
+	}
+
+	for _, schemaType := range schema.Type {
+		switch schemaType {
+		case "string":
+			return raw
+		case "integer":
+			if result, err := strconv.Atoi(raw); err == nil {
+				return result
+			}
+		case "number":
+			if result, err := strconv.ParseFloat(raw, 64); err == nil {
+				return result
+			}
+		case "boolean":
+			if result, err := strconv.ParseBool(raw); err == nil {
+				return result
+			}
+		case NullValue:
+			if raw == "" || raw == NullValue {
+				return nil
+			}
+		case "object", "array":
+			var result any
+			if err := yaml.Unmarshal([]byte(raw), &result); err == nil && typeOfValue(result) == schemaType {
+				return result
+			}
+		}
+	}
+
+	return raw
+}
+
+// cloneValue returns a deep copy of maps and slices, other values are returned as-is
+func cloneValue(value any) any {
+	if values, isMap := asMapStringAny(value); isMap {
+		result := make(map[string]any, len(values))
+		for key, nested := range values {
+			result[key] = cloneValue(nested)
+		}
+
+		return result
+	}
+
+	if items, isSlice := asSliceAny(value); isSlice {
+		result := make([]any, len(items))
+		for i, item := range items {
+			result[i] = cloneValue(item)
+		}
+
+		return result
+	}
+
+	return value
+}
+
+// envOverrides converts the environment variables that start with the prefix into override paths, where a double
+// underscore separates keys, e.g. APP_DB__POOL__SIZE becomes db.pool.size for prefix APP
+func envOverrides(prefix string, environ []string) map[string]any {
+	prefix = strings.TrimSuffix(prefix, "_") + "_"
+	result := make(map[string]any)
+
+	for _, variable := range environ {
+		name, value, _ := strings.Cut(variable, "=")
+
+		path, hasPrefix := strings.CutPrefix(name, prefix)
+		if !hasPrefix || path == "" {
+			continue
+		}
+
+		result[strings.ToLower(strings.ReplaceAll(path, "__", "."))] = value
+	}
+
+	return result
+}
+
+// WithOverridePaths allows you to override values using paths instead of nested maps, for example
+// `db.pool.size` or `servers[1].host`. String values are converted to the type the schema declares for the
+// path, so values from the command line can be used as-is. The paths take precedence over WithOverrideValues.
+func WithOverridePaths(paths map[string]any) Option {
+	return func(c *Config) {
+		if c.PathOverrides == nil {
+			c.PathOverrides = make(map[string]any, len(paths))
+		}
+
+		maps.Copy(c.PathOverrides, paths)
+	}
+}
+
+// WithEnvOverrides reads override values from the environment variables that start with the given prefix, in which
+// a double underscore separates keys: APP_DB__POOL__SIZE=10 sets `db.pool.size` for prefix APP. Keys are matched
+// case-insensitively and the values are converted like WithOverridePaths.
+func WithEnvOverrides(prefix string) Option {
+	return WithOverridePaths(envOverrides(prefix, os.Environ()))
+}
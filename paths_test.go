@@ -0,0 +1,218 @@
+package scheyaml
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath_ReturnsExpectedSegments(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path string
+
+		expected []pathSegment
+	}{
+		"single key": {
+			path:     "name",
+			expected: []pathSegment{{key: "name"}},
+		},
+		"nested keys": {
+			path:     "db.pool.size",
+			expected: []pathSegment{{key: "db"}, {key: "pool"}, {key: "size"}},
+		},
+		"index": {
+			path:     "servers[1].host",
+			expected: []pathSegment{{key: "servers"}, {index: 1, isIndex: true}, {key: "host"}},
+		},
+		"nested index": {
+			path:     "matrix[1][2]",
+			expected: []pathSegment{{key: "matrix"}, {index: 1, isIndex: true}, {index: 2, isIndex: true}},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := parsePath(testData.path)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestParsePath_ReturnsErrorOnInvalidPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{"", "a..b", "a[", "a[b]", "a[-1]", "a[1]b", "[1]"}
+
+	for _, path := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := parsePath(path)
+
+			// Assert
+			require.ErrorIs(t, err, ErrInvalidInput)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestExpandPaths_ReturnsExpectedOverrides(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "logLevel": {"type": "string"},
+    "db": {
+      "type": "object",
+      "properties": {
+        "pool": {"type": "object", "properties": {"size": {"type": "integer"}}},
+        "debug": {"type": "boolean"},
+        "timeout": {"type": ["null", "number"]}
+      }
+    },
+    "servers": {
+      "type": "array",
+      "items": {"type": "object", "properties": {"host": {"type": "string"}, "port": {"type": "integer"}}}
+    },
+    "tags": {"type": "array", "items": {"type": "string"}},
+    "limits": {"type": "object", "additionalProperties": {"type": "integer"}}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	overrides := map[string]any{
+		"db":      map[string]any{"debug": true},
+		"servers": []any{map[string]any{"host": "a", "port": 80}},
+	}
+
+	paths := map[string]any{
+		"loglevel":        "debug",
+		"db.pool.size":    "10",
+		"db.debug":        "false",
+		"db.timeout":      "null",
+		"servers[0].port": "8080",
+		"servers.2.host":  "c",
+		"tags[0]":         123,
+		"limits.cpu":      "2",
+	}
+
+	// Act
+	result, err := expandPaths(schema, overrides, paths)
+
+	// Assert
+	require.NoError(t, err)
+
+	expected := map[string]any{
+		"logLevel": "debug",
+		"db":       map[string]any{"debug": false, "pool": map[string]any{"size": 10}, "timeout": nil},
+		"servers": []any{
+			map[string]any{"host": "a", "port": 8080},
+			map[string]any{},
+			map[string]any{"host": "c"},
+		},
+		"tags":   []any{123},
+		"limits": map[string]any{"cpu": 2},
+	}
+	assert.Equal(t, expected, result)
+
+	// the given overrides are left untouched
+	assert.Equal(t, map[string]any{"debug": true}, overrides["db"])
+	assert.Equal(t, []any{map[string]any{"host": "a", "port": 80}}, overrides["servers"])
+}
+
+func TestCoerceValue_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		schema *jsonschema.Schema
+		value  any
+
+		expected any
+	}{
+		"no schema":            {schema: nil, value: "1", expected: "1"},
+		"not a string":         {schema: &jsonschema.Schema{Type: []string{"string"}}, value: 1, expected: 1},
+		"string":               {schema: &jsonschema.Schema{Type: []string{"string"}}, value: "1", expected: "1"},
+		"integer":              {schema: &jsonschema.Schema{Type: []string{"integer"}}, value: "1", expected: 1},
+		"number":               {schema: &jsonschema.Schema{Type: []string{"number"}}, value: "1.5", expected: 1.5},
+		"boolean":              {schema: &jsonschema.Schema{Type: []string{"boolean"}}, value: "true", expected: true},
+		"null":                 {schema: &jsonschema.Schema{Type: []string{"null"}}, value: "", expected: nil},
+		"object":               {schema: &jsonschema.Schema{Type: []string{"object"}}, value: `{"a": 1}`, expected: map[string]any{"a": 1}},
+		"array":                {schema: &jsonschema.Schema{Type: []string{"array"}}, value: "[1, 2]", expected: []any{1, 2}},
+		"first matching type":  {schema: &jsonschema.Schema{Type: []string{"integer", "string"}}, value: "auto", expected: "auto"},
+		"invalid value":        {schema: &jsonschema.Schema{Type: []string{"integer"}}, value: "abc", expected: "abc"},
+		"array is not a value": {schema: &jsonschema.Schema{Type: []string{"array"}}, value: "abc", expected: "abc"},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := coerceValue(testData.schema, testData.value)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestEnvOverrides_ReturnsPathsForPrefix(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	environ := []string{
+		"APP_DB__POOL__SIZE=10",
+		"APP_LOG_LEVEL=debug",
+		"APP_SERVERS__1__HOST=x",
+		"APP_=ignored",
+		"OTHER_NAME=ignored",
+		"APPLICATION=ignored",
+	}
+
+	// Act
+	result := envOverrides("APP", environ)
+
+	// Assert
+	expected := map[string]any{
+		"db.pool.size":   "10",
+		"log_level":      "debug",
+		"servers.1.host": "x",
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestSchemaToYAML_AppliesOverridePaths(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "db": {"type": "object", "properties": {"port": {"type": "integer", "default": 5432}}},
+    "name": {"type": "string", "default": "abc"}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	// Act
+	result, err := SchemaToYAML(schema, WithOverrideValues(map[string]any{"name": "def"}), WithOverridePaths(map[string]any{
+		"db.port": "6543",
+	}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "db:\n    port: 6543\nname: def\n", string(result))
+}
@@ -0,0 +1,210 @@
+package scheyaml
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+// Origins of values, see WithProvenanceComments
+const (
+	originDefault    = "default"
+	originOverride   = "override"
+	originAdditional = "additionalProperties"
+)
+
+// inheritedOrigins labels the schemas that the property inherits from the pattern properties of its parent (see
+// Config.PatternProperties) with the pattern they came from. Returns nil if provenance isn't tracked.
+func (c *Config) inheritedOrigins(propertyName string) map[*jsonschema.Schema]string {
+	if !c.ProvenanceComments {
+		return nil
+	}
+
+	result := make(map[*jsonschema.Schema]string)
+
+	for _, patternSchema := range c.PatternProperties {
+		if patternSchema.Properties != nil {
+			if property, hasProperty := (*patternSchema.Properties)[propertyName]; hasProperty {
+				result[property] = c.origins[patternSchema]
+			}
+		}
+
+		labelPatterns(result, patternSchema)
+	}
+
+	return result
+}
+
+// trackOrigin records where the schema of the property came from, given the object schema it's a property of. The
+// pattern properties and additionalProperties of the object are labelled, if the property is described by one of them
+// that becomes its origin. Otherwise the origin of the object itself applies.
+func (c *Config) trackOrigin(parent *jsonschema.Schema, property *jsonschema.Schema, parentOrigin string) {
+	if !c.ProvenanceComments {
+		return
+	}
+
+	if c.origins == nil {
+		c.origins = make(map[*jsonschema.Schema]string)
+	}
+
+	labelPatterns(c.origins, parent)
+
+	if additional, ok := additionalSchema(parent); ok {
+		c.origins[additional] = originAdditional
+		c.origins[resolveRef(additional)] = originAdditional
+	}
+
+	c.origin = parentOrigin
+	if label, ok := c.origins[property]; ok && label != "" {
+		c.origin = label
+	}
+}
+
+// labelPatterns adds the pattern properties of the schema to the origins, labelled as `patternProperties[<pattern>]`
+func labelPatterns(origins map[*jsonschema.Schema]string, schema *jsonschema.Schema) {
+	if schema == nil || schema.PatternProperties == nil {
+		return
+	}
+
+	for pattern, patternSchema := range *schema.PatternProperties {
+		if patternSchema != nil {
+			origins[patternSchema] = "patternProperties[" + pattern + "]"
+			origins[resolveRef(patternSchema)] = "patternProperties[" + pattern + "]"
+		}
+	}
+}
+
+// defaultOrigin returns the origin of the default value of the schema, which is either the schema that is being
+// rendered or one of the inherited pattern properties
+func (c *Config) defaultOrigin(schema *jsonschema.Schema, rendered *jsonschema.Schema) string {
+	if schema != rendered {
+		if label, ok := c.origins[schema]; ok && label != "" {
+			return label
+		}
+	}
+
+	if c.origin != "" {
+		return c.origin
+	}
+
+	return originDefault
+}
+
+// overrideOrigin returns the origin of the override value at the path of the config, which includes the index of the
+// layer it came from if WithOverrideLayers is used. Values within the items of an array are attributed to the layer of
+// the array as a whole, unless they were set using a path or taken from the default items by WithArrayMerge.
+func (c *Config) overrideOrigin() string {
+	path := c.path
+
+	for {
+		if label, ok := c.overrideOrigins[path]; ok {
+			return label
+		}
+
+		if index, ok := c.OverrideSources[path]; ok {
+			return "layers[" + strconv.Itoa(index) + "]"
+		}
+
+		if !lastItem.MatchString(path) {
+			return originOverride
+		}
+
+		path = lastItem.ReplaceAllString(path, "")
+	}
+}
+
+// lastItem matches the index of the last array item in a path and the keys within that item, e.g. `[1].name` of
+// `servers[1].name`
+var lastItem = regexp.MustCompile(`\[\d+][^\[]*$`)
+
+// pathOrigins labels the values of the path overrides as override values, as they take precedence over the values of
+// the layers they may replace. String values are converted like expandPaths does, so the paths match the rendered ones.
+func pathOrigins(schema *jsonschema.Schema, paths map[string]any) (map[string]string, error) {
+	values, err := expandPaths(schema, nil, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	labelValues(result, "", values, originOverride)
+
+	return result, nil
+}
+
+// labelMergedItems labels the values of the items merged by WithArrayMerge that come from the default items of the
+// array, overridden is the index of the override item that was merged into every item or -1 if there is none
+func (c *Config) labelMergedItems(items []any, overridden []int, overrides []any) {
+	if !c.ProvenanceComments {
+		return
+	}
+
+	if c.overrideOrigins == nil {
+		c.overrideOrigins = make(map[string]string)
+	}
+
+	for i, item := range items {
+		path := c.path + "[" + strconv.Itoa(i) + "]"
+
+		if overridden[i] < 0 {
+			labelValues(c.overrideOrigins, path, item, originDefault)
+			continue
+		}
+
+		labelMerged(c.overrideOrigins, path, item, overrides[overridden[i]])
+	}
+}
+
+// labelMerged labels the values of the merged object that are not part of the override object as default values, if
+// either is not an object the override replaced the default entirely
+func labelMerged(origins map[string]string, path string, merged any, override any) {
+	mergedValues, isMap := asMapStringAny(merged)
+	overrideValues, overrideIsMap := asMapStringAny(override)
+
+	if !isMap || !overrideIsMap {
+		return
+	}
+
+	for key, value := range mergedValues {
+		if nested, ok := overrideValues[key]; ok {
+			labelMerged(origins, joinPath(path, key), value, nested)
+			continue
+		}
+
+		labelValues(origins, joinPath(path, key), value, originDefault)
+	}
+}
+
+// labelValues labels the scalar values nested in the value with the given origin by their path
+func labelValues(origins map[string]string, path string, value any, label string) {
+	if values, isMap := asMapStringAny(value); isMap {
+		for key, nested := range values {
+			labelValues(origins, joinPath(path, key), nested, label)
+		}
+
+		return
+	}
+
+	if items, isSlice := asSliceAny(value); isSlice {
+		for i, item := range items {
+			labelValues(origins, path+"["+strconv.Itoa(i)+"]", item, label)
+		}
+
+		return
+	}
+
+	// arrays are padded with nil when a path refers to a later item
+	if value != nil {
+		origins[path] = label
+	}
+}
+
+// WithProvenanceComments adds a line comment to every scalar value that describes where the value came from:
+// `from: override` for override values, `from: default` for default values of the schema and for example
+// `from: patternProperties[^.*-name$]` for defaults of pattern properties. With WithOverrideLayers the index of the
+// layer is given instead, e.g. `from: layers[1]`. Values without a default keep the TODO comment.
+func WithProvenanceComments() Option {
+	return func(c *Config) {
+		c.ProvenanceComments = true
+	}
+}
@@ -0,0 +1,208 @@
+package scheyaml
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWithProvenanceComments_DescribesOriginOfValues(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData, err := os.ReadFile(path.Join("testdata", "test-schema-nested-pattern-properties.json"))
+	require.NoError(t, err)
+
+	schema, err := jsonschema.NewCompiler().Compile(inputData)
+	require.NoError(t, err)
+
+	overrides := map[string]any{
+		"friendly-name":  "scheyaml",
+		"service-config": map[string]any{"port": 8081},
+		"tracing-config": map[string]any{},
+	}
+
+	cfg := NewConfig()
+	cfg.ValueOverrides = overrides
+	cfg.ProvenanceComments = true
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	expected := `# Config
+friendly-name: scheyaml # from: override
+service-config:
+    host: localhost # from: default
+    name: unset # from: patternProperties[^.*-config$]
+    port: 8081 # from: override
+    source:
+        git: dev.azure.com # from: patternProperties[^.*-config$]
+        sha: null # TODO: Fill this in
+    version: "1.0" # from: patternProperties[^.*-config$]
+service-name: myapp # from: default
+tracing-config:
+    name: unset # from: patternProperties[^.*-config$]
+    source:
+        git: dev.azure.com # from: patternProperties[^.*-config$]
+        sha: null # TODO: Fill this in
+    version: "1.0" # from: patternProperties[^.*-config$]
+tracing-name: myapp.localhost # from: default
+`
+
+	assert.Equal(t, expected, string(actualData))
+}
+
+func TestWithProvenanceComments_DescribesLayerOfValues(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema, err := jsonschema.NewCompiler().Compile([]byte(`{
+  "type": "object",
+  "properties": {
+    "db": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost"},
+        "port": {"type": "integer", "default": 5432},
+        "user": {"type": "string"}
+      }
+    },
+    "tags": {"type": "array", "items": {"type": "string"}}
+  }
+}`))
+	require.NoError(t, err)
+
+	base := map[string]any{"db": map[string]any{"host": "db.example.com"}, "tags": []any{"a"}}
+	production := map[string]any{"db": map[string]any{"port": 6432}}
+
+	// Act
+	result, err := SchemaToYAML(schema,
+		WithOverrideLayers(base, production),
+		WithOverridePaths(map[string]any{"db.user": "admin"}),
+		WithProvenanceComments(),
+	)
+
+	// Assert
+	require.NoError(t, err)
+
+	expected := `db:
+    host: db.example.com # from: layers[0]
+    port: 6432 # from: layers[1]
+    user: admin # from: override
+tags:
+    - a # from: layers[0]
+`
+
+	assert.Equal(t, expected, string(result))
+}
+
+func TestWithProvenanceComments_DescribesPathsAndItemsOfLayers(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema, err := jsonschema.NewCompiler().Compile([]byte(`{
+  "type": "object",
+  "properties": {
+    "db": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string"},
+        "port": {"type": "integer"}
+      }
+    },
+    "servers": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "port": {"type": "integer"}
+        }
+      }
+    }
+  }
+}`))
+	require.NoError(t, err)
+
+	base := map[string]any{
+		"db":      map[string]any{"host": "localhost", "port": 1},
+		"servers": []any{map[string]any{"name": "api", "port": 80}},
+	}
+
+	// Act
+	result, err := SchemaToYAML(schema,
+		WithOverrideLayers(base),
+		WithOverridePaths(map[string]any{"db.port": "2", "servers[0].port": "8080"}),
+		WithProvenanceComments(),
+	)
+
+	// Assert
+	require.NoError(t, err)
+
+	expected := `db:
+    host: localhost # from: layers[0]
+    port: 2 # from: override
+servers:
+    - name: api # from: layers[0]
+      port: 8080 # from: override
+`
+
+	assert.Equal(t, expected, string(result))
+}
+
+func TestWithProvenanceComments_DescribesMergedDefaultItems(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := []byte(`{
+  "type": "object",
+  "properties": {
+    "servers": {
+      "type": "array",
+      "x-merge-key": "name",
+      "items": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "port": {"type": "integer"}
+        }
+      },
+      "default": [{"name": "api", "port": 80}, {"name": "worker", "port": 81}]
+    }
+  }
+}`)
+
+	schema, err := jsonschema.NewCompiler().Compile(inputData)
+	require.NoError(t, err)
+
+	document, err := NewSchemaDocument(schema, inputData)
+	require.NoError(t, err)
+
+	overrides := map[string]any{"servers": []any{map[string]any{"name": "worker", "port": 8081}}}
+
+	// Act
+	result, err := SchemaToYAML(schema,
+		WithOverrideValues(overrides),
+		WithArrayMerge(MergeItemsByKey, document),
+		WithProvenanceComments(),
+	)
+
+	// Assert
+	require.NoError(t, err)
+
+	expected := `servers:
+    - name: api # from: default
+      port: 80 # from: default
+    - name: worker # from: override
+      port: 8081 # from: override
+`
+
+	assert.Equal(t, expected, string(result))
+}
@@ -0,0 +1,106 @@
+package scheyaml
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/kaptinlin/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedValue replaces the values of sensitive properties, see WithRedaction
+const RedactedValue = "<redacted>"
+
+// Redaction determines which values are sensitive and replaced with RedactedValue, see WithRedaction
+type Redaction struct {
+	// Document the schema was compiled from, used to find properties with the `x-sensitive: true` extension. Optional.
+	Document *SchemaDocument
+
+	// Matcher reports whether the property at the path (e.g. `database.password` or `servers[1].token`) is sensitive.
+	// Optional.
+	Matcher func(path string) bool
+}
+
+// sensitive returns true if the property at the path is sensitive, which is the case if it's marked as writeOnly, if
+// one of the raw schemas has the `x-sensitive: true` extension or if the matcher matches the path
+func (r *Redaction) sensitive(schemas []*jsonschema.Schema, documented []*jsonschema.Schema, path string) bool {
+	if r == nil {
+		return false
+	}
+
+	if flagged(documented, writeOnly) {
+		return true
+	}
+
+	for _, schema := range schemas {
+		if r.marked(schema, nil) {
+			return true
+		}
+	}
+
+	return r.Matcher != nil && r.Matcher(path)
+}
+
+// marked returns true if the schema, the target of its reference or one of its allOf members has the
+// `x-sensitive: true` extension. Only schemas of the document are found, so copies of a schema (e.g. with a default
+// value pushed down) are not marked themselves, but the allOf members and reference they contain are.
+func (r *Redaction) marked(schema *jsonschema.Schema, seen []*jsonschema.Schema) bool {
+	if schema == nil || slices.Contains(seen, schema) {
+		return false
+	}
+
+	if node, ok := r.Document.keyword(schema, "x-sensitive"); ok && node.Kind == yaml.ScalarNode && node.Value == "true" {
+		return true
+	}
+
+	seen = append(seen, schema)
+
+	if schema.ResolvedRef != nil && r.marked(schema.ResolvedRef, seen) {
+		return true
+	}
+
+	return slices.ContainsFunc(schema.AllOf, func(member *jsonschema.Schema) bool {
+		return r.marked(member, seen)
+	})
+}
+
+// redactNested replaces the values nested in the node of a value that isn't described by the schema, of which the
+// path matches the matcher. The path of the node itself has been checked already.
+func (r *Redaction) redactNested(node *yaml.Node, path string) {
+	if r == nil || r.Matcher == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			r.redactChild(node, i+1, joinPath(path, node.Content[i].Value))
+		}
+	case yaml.SequenceNode:
+		for i := range node.Content {
+			r.redactChild(node, i, path+"["+strconv.Itoa(i)+"]")
+		}
+	default:
+	}
+}
+
+// redactChild replaces the child of the node at the index with RedactedValue if the path matches, or redacts the
+// values nested in it otherwise
+func (r *Redaction) redactChild(node *yaml.Node, index int, path string) {
+	if r.Matcher(path) {
+		node.Content[index] = &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Value: RedactedValue}
+		return
+	}
+
+	r.redactNested(node.Content[index], path)
+}
+
+// WithRedaction replaces the values of sensitive properties with RedactedValue, so the output can be logged or shared
+// safely. Properties are sensitive if they're marked as writeOnly, have the `x-sensitive: true` extension in the
+// document or if the path of the property matches. Both the document and the matcher may be nil. The real values
+// are still validated against the schema.
+func WithRedaction(document *SchemaDocument, matcher func(path string) bool) Option {
+	return func(c *Config) {
+		c.Redaction = &Redaction{Document: document, Matcher: matcher}
+	}
+}
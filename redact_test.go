@@ -0,0 +1,214 @@
+package scheyaml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRedaction_RedactsSensitiveValues(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := []byte(`{
+  "type": "object",
+  "properties": {
+    "database": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost"},
+        "password": {"type": "string", "writeOnly": true}
+      }
+    },
+    "token": {"type": "string", "x-sensitive": true},
+    "user": {"type": "string"}
+  }
+}`)
+
+	schema, err := jsonschema.NewCompiler().Compile(inputData)
+	require.NoError(t, err)
+
+	document, err := NewSchemaDocument(schema, inputData)
+	require.NoError(t, err)
+
+	overrides := map[string]any{
+		"database": map[string]any{"password": "hunter2"},
+		"token":    "abc",
+		"user":     "admin",
+		"apiKey":   "secret",
+	}
+
+	matcher := func(path string) bool {
+		return strings.EqualFold(path, "apikey")
+	}
+
+	// Act
+	result, err := SchemaToYAML(schema, WithOverrideValues(overrides), WithRedaction(document, matcher))
+
+	// Assert
+	require.NoError(t, err)
+
+	expected := `apiKey: <redacted>
+database:
+    host: localhost
+    password: <redacted>
+token: <redacted>
+user: admin
+`
+
+	assert.Equal(t, expected, string(result))
+}
+
+func TestWithRedaction_RedactsSensitiveItems(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := []byte(`{
+  "type": "object",
+  "properties": {
+    "hosts": {"type": "array", "items": {"type": "string"}},
+    "passwords": {"type": "array", "items": {"type": "string", "writeOnly": true}},
+    "tokens": {"type": "array", "items": {"type": "string", "x-sensitive": true}, "default": ["abc"]}
+  }
+}`)
+
+	schema, err := jsonschema.NewCompiler().Compile(inputData)
+	require.NoError(t, err)
+
+	document, err := NewSchemaDocument(schema, inputData)
+	require.NoError(t, err)
+
+	overrides := map[string]any{
+		"hosts":     []any{"localhost", "internal"},
+		"passwords": []any{"hunter2", "letmein"},
+	}
+
+	matcher := func(path string) bool {
+		return path == "hosts[1]"
+	}
+
+	// Act
+	result, err := SchemaToYAML(schema, WithOverrideValues(overrides), WithRedaction(document, matcher))
+
+	// Assert
+	require.NoError(t, err)
+
+	expected := `hosts:
+    - localhost
+    - <redacted>
+passwords:
+    - <redacted>
+    - <redacted>
+tokens:
+    - <redacted>
+`
+
+	assert.Equal(t, expected, string(result))
+}
+
+func TestWithRedaction_RedactsValuesOfCopiedSchemasAndUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		inputData string
+		overrides map[string]any
+
+		expected string
+	}{
+		"default of the parent": {
+			inputData: `{
+  "type": "object",
+  "properties": {
+    "db": {
+      "type": "object",
+      "default": {"password": "s3cret"},
+      "properties": {
+        "password": {"type": "string", "x-sensitive": true}
+      }
+    }
+  }
+}`,
+
+			expected: `db:
+    password: <redacted>
+`,
+		},
+		"allOf member": {
+			inputData: `{
+  "type": "object",
+  "properties": {
+    "a": {"allOf": [{"type": "string", "x-sensitive": true}]}
+  }
+}`,
+			overrides: map[string]any{"a": "p"},
+
+			expected: `a: <redacted>
+`,
+		},
+		"nested value of an unknown key": {
+			inputData: `{"type": "object"}`,
+			overrides: map[string]any{"extra": map[string]any{"password": "leak", "user": "admin"}},
+
+			expected: `extra:
+    password: <redacted>
+    user: admin
+`,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			schema, err := jsonschema.NewCompiler().Compile([]byte(testData.inputData))
+			require.NoError(t, err)
+
+			document, err := NewSchemaDocument(schema, []byte(testData.inputData))
+			require.NoError(t, err)
+
+			matcher := func(path string) bool {
+				return path == "extra.password"
+			}
+
+			// Act
+			result, err := SchemaToYAML(schema, WithOverrideValues(testData.overrides), WithRedaction(document, matcher))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, string(result))
+		})
+	}
+}
+
+func TestWithRedaction_ValidatesRealValues(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema, err := jsonschema.NewCompiler().Compile([]byte(`{
+  "type": "object",
+  "properties": {
+    "password": {"type": "string", "writeOnly": true, "minLength": 8}
+  }
+}`))
+	require.NoError(t, err)
+
+	// Act
+	result, err := SchemaToYAML(schema, WithOverrideValues(map[string]any{"password": "short"}), WithRedaction(nil, nil))
+
+	// Assert
+	var schemaErr *InvalidSchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Nil(t, result)
+}
+
+func TestRedaction_SensitiveIsFalseIfNil(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var redaction *Redaction
+
+	// Act
+	result := redaction.sensitive(nil, nil, "password")
+
+	// Assert
+	assert.False(t, result)
+}
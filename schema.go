@@ -1,12 +1,16 @@
 package scheyaml
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"maps"
+	"math"
+	"math/big"
 	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/kaptinlin/jsonschema"
@@ -21,6 +25,16 @@ var ErrParsing = errors.New("failed to parse/process jsonschema")
 // SkipValue where the key is omitted entirely
 const NullValue = "null"
 
+// additionalExampleKey is the key of the example entry of a map-like object, see scheYAMLObject
+const additionalExampleKey = "<name>"
+
+// Tags of YAML scalar nodes
+const (
+	strTag   = "!!str"
+	nullTag  = "!!null"
+	mergeTag = "!!merge"
+)
+
 // skipValue type alias used as a sentinel to omit a particular key from the result
 type skipValue bool
 
@@ -36,7 +50,44 @@ func scheYAML(rootSchema *jsonschema.Schema, cfg *Config) (*yaml.Node, error) {
 
 	// If we're dealing with a reference, we'll continue with a resolved version of it
 	if rootSchema.Ref != "" {
-		return scheYAML(rootSchema.ResolvedRef, cfg)
+		target := resolveRef(rootSchema)
+		if target.Ref != "" {
+			return nil, fmt.Errorf("circular reference %q: %w", rootSchema.Ref, ErrParsing)
+		}
+
+		// a recursive reference is only followed as long as there are override values to render
+		if recursion := cfg.recursion(target); recursion > 0 {
+			if _, hasValue := cfg.currentValue(); !hasValue {
+				result.Kind = yaml.ScalarNode
+				result.Value = NullValue
+				result.LineComment = "recursive: see " + rootSchema.Ref
+
+				return result, nil
+			}
+
+			if recursion > cfg.MaxDepth {
+				return nil, &MaxDepthError{Path: cfg.path, MaxDepth: cfg.MaxDepth}
+			}
+		}
+
+		return scheYAML(target, cfg)
+	}
+
+	cfg = cfg.visit(rootSchema)
+
+	// Fold allOf members and the selected anyOf/oneOf branch into one schema
+	unfolded := rootSchema
+	rootSchema = withConstValue(effectiveSchema(rootSchema, cfg))
+
+	// the title and description of the root schema document the whole file, as do its anyOf/oneOf branches of which
+	// only one is rendered
+	if cfg.path == "" && len(cfg.visited) == 1 {
+		documentation := formatHeadComment(derefString(rootSchema.Title), derefString(rootSchema.Description), nil, cfg.LineLength)
+		result.HeadComment = joinComments(result.HeadComment, documentation)
+
+		if selected, ok := selectBranch(unfolded, cfg); ok {
+			result.HeadComment = joinComments(result.HeadComment, formatAlternatives(unfolded, selected))
+		}
 	}
 
 	// This is to prevent a slice out of bounds panic, but shouldn't happen under normal circumstances
@@ -44,40 +95,37 @@ func scheYAML(rootSchema *jsonschema.Schema, cfg *Config) (*yaml.Node, error) {
 		return result, nil
 	}
 
-	// TODO: Currently we default to the first type in the list, if more types are defined they are ignored
-	switch rootSchema.Type[0] {
+	// Schemas may allow multiple types, of which the one that matches the value is rendered
+	schemaType := selectType(rootSchema, cfg)
+
+	switch schemaType {
 	case "object":
 		result.Kind = yaml.MappingNode
 
-		objectContent, err := scheYAMLObject(rootSchema, cfg)
+		objectContent, commented, err := scheYAMLObject(rootSchema, cfg)
 		if err != nil {
 			return nil, err
 		}
 
 		result.Content = objectContent
 
+		// optional properties that are commented out follow the last property, or are placed inside the braces of
+		// an object without any other properties
+		if commented != "" && len(objectContent) > 0 {
+			objectContent[len(objectContent)-2].FootComment = commented
+		} else if commented != "" {
+			result.HeadComment = joinComments(result.HeadComment, commented)
+		}
+
 	case "array":
 		result.Kind = yaml.SequenceNode
 
-		if len(cfg.ItemsOverrides) > 0 {
-			for i := range len(cfg.ItemsOverrides) {
-				arrayContent, err := scheYAML(rootSchema.Items, cfg.forIndex(i))
-				if err != nil {
-					return nil, err
-				}
-
-				result.Content = append(result.Content, arrayContent)
-			}
-
-			break
-		}
-
-		arrayContent, err := scheYAML(rootSchema.Items, cfg)
+		arrayContent, err := scheYAMLArray(rootSchema, cfg)
 		if err != nil {
 			return nil, err
 		}
 
-		result.Content = []*yaml.Node{arrayContent}
+		result.Content = arrayContent
 
 	case NullValue:
 		result.Kind = yaml.ScalarNode
@@ -90,22 +138,45 @@ func scheYAML(rootSchema *jsonschema.Schema, cfg *Config) (*yaml.Node, error) {
 		// derive a schema with default from the highest specificity (the rootschema) to lower (pattern properties in order)
 		schemas := append([]*jsonschema.Schema{rootSchema}, cfg.PatternProperties...)
 		if schema, ok := coalesce(schemas, withDefault); ok {
+			if cfg.ProvenanceComments {
+				result.LineComment = "from: " + cfg.defaultOrigin(schema, rootSchema)
+			}
+
 			rootSchema = schema
 		}
 
 		if cfg.HasOverride && (all(schemas, nullable) || cfg.ValueOverride != nil) {
-			if cfg.ValueOverride == nil {
-				cfg.ValueOverride = NullValue
+			if cfg.ProvenanceComments {
+				result.LineComment = "from: " + cfg.overrideOrigin()
 			}
 
-			result.Value = fmt.Sprint(cfg.ValueOverride)
+			// the NullValue sentinel is not a string, but an explicit null
+			if cfg.ValueOverride == nil || cfg.ValueOverride == NullValue {
+				result.Tag = nullTag
+				result.Value = NullValue
+
+				break
+			}
+
+			setScalarValue(result, cfg.ValueOverride, schemaType)
 
 			break
 		}
 
 		switch {
 		case rootSchema.Default != nil:
-			result.Value = fmt.Sprint(rootSchema.Default)
+			// objects or arrays may be the default of a schema that doesn't describe them
+			if valueNode, ok := nodeForValue(rootSchema.Default); ok && valueNode.Kind != yaml.ScalarNode {
+				valueNode.HeadComment = result.HeadComment
+				return valueNode, nil
+			}
+
+			setScalarValue(result, rootSchema.Default, schemaType)
+
+		case cfg.EnumPlaceholder && len(rootSchema.Enum) > 0:
+			// the first allowed value is a placeholder, so the TODO comment still applies
+			setScalarValue(result, rootSchema.Enum[0], schemaType)
+			result.LineComment = cfg.TODOComment
 
 		default:
 			result.LineComment = cfg.TODOComment
@@ -116,18 +187,171 @@ func scheYAML(rootSchema *jsonschema.Schema, cfg *Config) (*yaml.Node, error) {
 	return result, nil
 }
 
-// scheYAMLObject encapsulates the logic to scheYAML a schema of type "object"
-func scheYAMLObject(schema *jsonschema.Schema, cfg *Config) ([]*yaml.Node, error) { //nolint:gocyclo,cyclop,gocognit // Acceptable complexity, splitting this up is overkill
+// scheYAMLArray encapsulates the logic to scheYAML a schema of type "array", it returns the items of the override
+// values, the default value or otherwise placeholder items. Items are rendered using the prefixItems of a tuple and
+// the items schema for the remaining positions.
+func scheYAMLArray(schema *jsonschema.Schema, cfg *Config) ([]*yaml.Node, error) {
+	if schema.MaxItems != nil && float64(len(cfg.ItemsOverrides)) > *schema.MaxItems {
+		return nil, fmt.Errorf("%s: %d items exceed the maximum of %s: %w", cfg.path, len(cfg.ItemsOverrides), formatRat(ratOf(schema.MaxItems)), ErrInvalidInput)
+	}
+
+	if len(cfg.ItemsOverrides) > 0 {
+		result := make([]*yaml.Node, 0, len(cfg.ItemsOverrides))
+
+		for i, value := range cfg.ItemsOverrides {
+			// values beyond the tuple of an array without an items schema are rendered as-is
+			item := itemSchema(schema, i)
+			if item == nil {
+				item = withDefaultValue(nil, value)
+			}
+
+			itemContent, err := scheYAML(item, cfg.forIndex(i))
+			if err != nil {
+				return nil, err
+			}
+
+			itemContent = concealItem(schema, i, item, itemContent, cfg)
+			result = append(result, documentTupleItem(schema, i, itemContent, cfg.LineLength))
+		}
+
+		return result, nil
+	}
+
+	// a default value of the array itself is rendered item by item
+	if defaults, ok := asSliceAny(schema.Default); ok {
+		result := make([]*yaml.Node, 0, len(defaults))
+
+		for i, value := range defaults {
+			item := withDefaultValue(itemSchema(schema, i), value)
+
+			itemContent, err := scheYAML(item, cfg.forIndex(i))
+			if err != nil {
+				return nil, err
+			}
+
+			itemContent = concealItem(schema, i, item, itemContent, cfg)
+			result = append(result, documentTupleItem(schema, i, itemContent, cfg.LineLength))
+		}
+
+		return result, nil
+	}
+
+	placeholders := placeholderItems(schema, cfg)
+	result := make([]*yaml.Node, 0, len(placeholders))
+
+	for i, item := range placeholders {
+		itemContent, err := scheYAML(item, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		itemContent = concealItem(schema, i, item, itemContent, cfg)
+		result = append(result, documentTupleItem(schema, i, itemContent, cfg.LineLength))
+	}
+
+	return result, nil
+}
+
+// documentTupleItem adds the title and description of the prefixItems schema at the index to the head comment of the
+// item, as the meaning of a position in a tuple is not obvious otherwise
+func documentTupleItem(schema *jsonschema.Schema, index int, item *yaml.Node, lineLength uint) *yaml.Node {
+	if index >= len(schema.PrefixItems) {
+		return item
+	}
+
+	prefixItem := resolveRef(schema.PrefixItems[index])
+	documentation := formatHeadComment(derefString(prefixItem.Title), derefString(prefixItem.Description), nil, lineLength)
+	item.HeadComment = joinComments(documentation, item.HeadComment)
+
+	return item
+}
+
+// concealItem replaces the rendered item at the index of the array with the writeOnly placeholder if its schema is
+// marked as writeOnly and with RedactedValue if it's sensitive, like the values of properties. The item schema is the
+// schema the item was rendered with, the raw items schema of the array is used to find the `x-sensitive` extension.
+func concealItem(schema *jsonschema.Schema, index int, item *jsonschema.Schema, node *yaml.Node, cfg *Config) *yaml.Node {
+	documented := []*jsonschema.Schema{effectiveSchema(item, cfg)}
+
+	if cfg.WriteOnlyPlaceholder != "" && flagged(documented, writeOnly) {
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Value: cfg.WriteOnlyPlaceholder}
+	}
+
+	schemas := resolve([]*jsonschema.Schema{itemSchema(schema, index)})
+	if cfg.Redaction.sensitive(schemas, documented, cfg.path+"["+strconv.Itoa(index)+"]") {
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Value: RedactedValue}
+	}
+
+	return node
+}
+
+// placeholderItems returns the schemas of the items to render for an array without overrides or default value, which
+// are the prefixItems followed by the items that must match the contains schema. At least minItems items are returned
+// and if that results in no items at all, a single item is added unless EmptyArrays is set. No items are returned if
+// placeholders are skipped, as when the values are resolved rather than documented.
+func placeholderItems(schema *jsonschema.Schema, cfg *Config) []*jsonschema.Schema {
+	if cfg.skipPlaceholders {
+		return nil
+	}
+
+	result := slices.Clone(schema.PrefixItems)
+
+	if schema.Contains != nil {
+		contains := 1
+		if schema.MinContains != nil {
+			contains = int(*schema.MinContains)
+		}
+
+		// the contains schema takes precedence, the items schema provides the type and properties it doesn't define
+		containsItem := resolveRef(schema.Contains)
+		if item := itemSchema(schema, len(result)); item != nil {
+			containsItem = mergeSchemas([]*jsonschema.Schema{containsItem, resolveRef(item)})
+		}
+
+		for range contains {
+			result = append(result, containsItem)
+		}
+	}
+
+	count := len(result)
+	if schema.MinItems != nil {
+		count = max(count, int(*schema.MinItems))
+	}
+
+	if count == 0 && !cfg.EmptyArrays {
+		count = 1
+	}
+
+	for i := len(result); i < count; i++ {
+		item := itemSchema(schema, i)
+		if item == nil {
+			break
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// scheYAMLObject encapsulates the logic to scheYAML a schema of type "object", it returns the key and value nodes of
+// the properties and the optional properties that are commented out (see WithOptionalAsComments) which are not
+// followed by another property. Override keys that are neither a property nor match a pattern property are rendered
+// using the additionalProperties schema, if there are none an example entry is commented out instead.
+func scheYAMLObject(schema *jsonschema.Schema, cfg *Config) ([]*yaml.Node, string, error) { //nolint:gocyclo,cyclop,gocognit,maintidx // Acceptable complexity, splitting this up is overkill
 	// exit early if either schema or config is not defined
 	if schema == nil || cfg == nil {
-		return nil, fmt.Errorf("nil schema or config supplied: %w", ErrParsing)
+		return nil, "", fmt.Errorf("nil schema or config supplied: %w", ErrParsing)
 	}
 
+	// the default of the object is pushed down to its properties, the original schema is kept to determine the order
+	original := schema
+	schema = withObjectDefault(schema)
+
 	// guard that all regexes are valid
 	if schema.PatternProperties != nil && len(*schema.PatternProperties) > 0 {
 		for pattern := range *schema.PatternProperties {
 			if _, err := regexp.Compile(pattern); err != nil {
-				return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+				return nil, "", fmt.Errorf("invalid pattern '%s': %w", pattern, err)
 			}
 		}
 	}
@@ -153,16 +377,32 @@ func scheYAMLObject(schema *jsonschema.Schema, cfg *Config) ([]*yaml.Node, error
 	properties = unique(properties)
 	sort.Strings(properties)
 
+	if cfg.PropertyOrder != nil {
+		slices.SortStableFunc(properties, func(a, b string) int {
+			return cfg.PropertyOrder(original, a, b)
+		})
+	}
+
+	additional, hasAdditionalSchema := additionalSchema(schema)
+
 	// exit early if nothing matches with an empty object definition
-	if len(properties) == 0 {
-		return []*yaml.Node{{Kind: yaml.MappingNode, Value: "{}"}}, nil
+	if len(properties) == 0 && (!hasAdditionalSchema || cfg.OnlyRequired) {
+		return []*yaml.Node{{Kind: yaml.MappingNode, Value: "{}"}}, "", nil
 	}
 
 	result := make([]*yaml.Node, 0, 2*len(properties)) //nolint:mnd // not a magic number, nodes come in pairs of key=node
+	// optional properties that are commented out are added to the head comment of the next property
+	var commented []string
+
+	// whether any of the properties is described by the additionalProperties schema
+	var hasAdditional bool
+
 	for _, propertyName := range properties {
 		override, hasOverride := cfg.overrideFor(propertyName)
+		commentOut := !hasOverride && cfg.OptionalAsComments && !required(schema, propertyName)
+
 		// if running in onlyRequired mode, emit required properties and overrides only
-		if !hasOverride && cfg.OnlyRequired && !required(schema, propertyName) {
+		if !hasOverride && cfg.OnlyRequired && !required(schema, propertyName) && !commentOut {
 			continue
 		} else if hasOverride && override == SkipValue {
 			// or if an override is supplied but it is the skip sentinel, continue
@@ -182,7 +422,18 @@ func scheYAMLObject(schema *jsonschema.Schema, cfg *Config) ([]*yaml.Node, error
 		patterns := patternPropertiesForProperty(schema, propertyName)
 		schemas = append(schemas, patterns...)
 
-		// resolve potential references in schemas
+		// keys that are neither a property nor match a pattern are described by additionalProperties, keys of the
+		// default value of the object have been added to the properties already
+		if len(schemas) == 0 && hasAdditionalSchema {
+			schemas = append(schemas, additional)
+		}
+
+		if len(patterns) == 0 && (original.Properties == nil || (*original.Properties)[propertyName] == nil) {
+			hasAdditional = true
+		}
+
+		// resolve potential references in schemas, the reference itself is used for rendering to detect recursion
+		unresolved, _ := coalesce(schemas, notNil)
 		schemas = resolve(schemas)
 
 		if inherited := cfg.PatternProperties; len(inherited) > 0 {
@@ -194,47 +445,111 @@ func scheYAMLObject(schema *jsonschema.Schema, cfg *Config) ([]*yaml.Node, error
 		}
 
 		rootschema, _ := coalesce(schemas, notNil)
+		propertyCfg := cfg.forProperty(propertyName, patterns)
+		propertyCfg.trackOrigin(schema, rootschema, cfg.origin)
+
+		// the properties of a property that is commented out are all rendered, as they're commented out as well
+		if commentOut {
+			propertyCfg.OptionalAsComments = false
+		}
 
 		// keyNode of the key: value pair in YAML
 		keyNode := &yaml.Node{
 			Kind:  yaml.ScalarNode,
+			Tag:   strTag,
 			Value: propertyName,
 		}
 
 		if rootschema == nil && hasOverride { // e.g. an override that is not contained in the schema
-			var valueNode yaml.Node
-			if b, marshalErr := yaml.Marshal(override); marshalErr != nil {
-				continue
-			} else if unmarshalErr := yaml.Unmarshal(b, &valueNode); unmarshalErr != nil {
-				continue
-			} else if len(valueNode.Content) == 0 {
-				continue
-			}
+			if cfg.Redaction.sensitive(nil, nil, propertyCfg.path) {
+				result = append(result, keyNode, &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Value: RedactedValue})
+			} else if valueNode, ok := nodeForValue(override); ok {
+				cfg.Redaction.redactNested(valueNode, propertyCfg.path)
 
-			result = append(result, keyNode, valueNode.Content[0])
+				if cfg.ProvenanceComments && valueNode.Kind == yaml.ScalarNode {
+					valueNode.LineComment = "from: " + propertyCfg.overrideOrigin()
+				}
+
+				result = append(result, keyNode, valueNode)
+			}
 
 			continue
 		} else if rootschema == nil {
 			continue // malformed node
 		}
 
-		// add a HeadComment to the schema if a node is found which has a description or examples
-		schemaWithDescription, hasDescription := coalesce(schemas, withDescription)
+		// documentation may also come from composed schemas (allOf, anyOf, oneOf), so use the effective schemas
+		documented := make([]*jsonschema.Schema, len(schemas))
+		for i, s := range schemas {
+			documented[i] = effectiveSchema(s, propertyCfg)
+		}
 
-		schemaWithExamples, hasExamples := coalesce(schemas, withExamples)
-		switch {
-		case hasDescription && hasExamples:
-			keyNode.HeadComment = formatHeadComment(*schemaWithDescription.Description, schemaWithExamples.Examples, cfg.LineLength)
-		case hasDescription:
-			keyNode.HeadComment = formatHeadComment(*schemaWithDescription.Description, []any{}, cfg.LineLength)
-		case hasExamples:
-			keyNode.HeadComment = formatHeadComment("", schemaWithExamples.Examples, cfg.LineLength)
+		// readOnly properties are managed by the application and deprecated properties may be left out, unless the
+		// user explicitly provides a value for them
+		if !hasOverride && ((!cfg.IncludeReadOnly && flagged(documented, readOnly)) || (cfg.SkipDeprecated && flagged(documented, deprecated))) {
+			continue
+		}
+
+		// pushing down the default of the object copies its properties, so extensions such as `x-merge-key` and
+		// `x-sensitive` are looked up in the original property as well
+		var property *jsonschema.Schema
+		if original.Properties != nil {
+			property = (*original.Properties)[propertyName]
+		}
+
+		raw := resolve(append([]*jsonschema.Schema{property}, schemas...))
+
+		// the override items of an array may patch the items of its default value, see WithArrayMerge
+		if defaultSchema, ok := coalesce(documented, withDefault); ok && len(propertyCfg.ItemsOverrides) > 0 {
+			if defaults, isSlice := asSliceAny(defaultSchema.Default); isSlice {
+				propertyCfg.ItemsOverrides = propertyCfg.mergeItems(raw, defaults, propertyCfg.ItemsOverrides)
+			}
+		}
+
+		// add a HeadComment to the schema if a node is found which has a title, description or examples
+		keyNode.HeadComment = formatDocumentation(schemas, documented, cfg.LineLength)
+
+		if flagged(documented, deprecated) {
+			keyNode.HeadComment = strings.TrimSuffix("DEPRECATED\n"+keyNode.HeadComment, "\n")
+		}
+
+		// list the types a property accepts, as only one of them is rendered
+		if typed, ok := coalesce(documented, notNil); ok {
+			keyNode.HeadComment = joinComments(keyNode.HeadComment, formatTypes(typed))
+		}
+
+		// list the values a property accepts
+		if enumerated, ok := coalesce(documented, withEnum); ok {
+			keyNode.HeadComment = joinComments(keyNode.HeadComment, formatEnum(enumerated.Enum))
+		}
+
+		// describe the validation constraints of the property, if requested
+		if constrained, ok := coalesce(documented, notNil); ok && cfg.ConstraintComments {
+			keyNode.HeadComment = joinComments(keyNode.HeadComment, formatConstraints(constrained))
+		}
+
+		// summarise the anyOf/oneOf branches, of which only one is rendered
+		if selected, ok := selectBranch(rootschema, propertyCfg); ok {
+			keyNode.HeadComment = joinComments(keyNode.HeadComment, formatAlternatives(rootschema, selected))
+		}
+
+		if unresolved == nil {
+			unresolved = rootschema
 		}
 
 		// else recursively determine the nodeValue using scheYAML
-		valueNode, err := scheYAML(rootschema, cfg.forProperty(propertyName, patterns))
+		valueNode, err := scheYAML(unresolved, propertyCfg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scheyaml %q: %w", propertyName, err)
+			return nil, "", fmt.Errorf("failed to scheyaml %q: %w", propertyName, err)
+		}
+
+		// the values of writeOnly properties, such as passwords, are not disclosed
+		if cfg.WriteOnlyPlaceholder != "" && flagged(documented, writeOnly) {
+			valueNode = &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Value: cfg.WriteOnlyPlaceholder}
+		}
+
+		if cfg.Redaction.sensitive(raw, documented, propertyCfg.path) {
+			valueNode = &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Value: RedactedValue}
 		}
 
 		// in case only
@@ -242,10 +557,222 @@ func scheYAMLObject(schema *jsonschema.Schema, cfg *Config) ([]*yaml.Node, error
 			valueNode.Value = "{}"
 		}
 
+		if commentOut {
+			block, err := commentedOut(keyNode, valueNode, cfg.Indent)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to comment out %q: %w", propertyName, err)
+			}
+
+			commented = append(commented, block)
+
+			continue
+		}
+
+		if len(commented) > 0 {
+			if keyNode.HeadComment != "" {
+				commented = append(commented, keyNode.HeadComment)
+			}
+
+			keyNode.HeadComment = strings.Join(commented, "\n")
+			commented = nil
+		}
+
 		result = append(result, keyNode, valueNode)
 	}
 
-	return result, nil
+	// illustrate what the entries of a map-like object look like, as there are none
+	if hasAdditionalSchema && !hasAdditional && !cfg.OnlyRequired {
+		example, err := additionalExample(additional, cfg)
+		if err != nil {
+			return nil, "", err
+		}
+
+		commented = append(commented, example)
+	}
+
+	return result, strings.Join(commented, "\n"), nil
+}
+
+// additionalExample renders an entry of the additionalProperties schema as YAML that is commented out, using
+// additionalExampleKey as the key
+func additionalExample(additional *jsonschema.Schema, cfg *Config) (string, error) {
+	propertyCfg := cfg.forProperty(additionalExampleKey, nil)
+	propertyCfg.OptionalAsComments = false
+
+	resolved := resolveRef(additional)
+
+	keyNode := &yaml.Node{
+		Kind:        yaml.ScalarNode,
+		Tag:         strTag,
+		Value:       additionalExampleKey,
+		HeadComment: formatDocumentation([]*jsonschema.Schema{resolved}, []*jsonschema.Schema{effectiveSchema(resolved, propertyCfg)}, cfg.LineLength),
+	}
+
+	valueNode, err := scheYAML(additional, propertyCfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to scheyaml additional properties: %w", err)
+	}
+
+	if len(valueNode.Content) == 0 && valueNode.Kind == yaml.MappingNode {
+		valueNode.Value = "{}"
+	}
+
+	block, err := commentedOut(keyNode, valueNode, cfg.Indent)
+	if err != nil {
+		return "", fmt.Errorf("failed to comment out additional properties: %w", err)
+	}
+
+	return block, nil
+}
+
+// commentedOut renders the key and value as YAML of which every line is to be commented out, preceded by the
+// head comment of the key
+func commentedOut(keyNode *yaml.Node, valueNode *yaml.Node, indent int) (string, error) {
+	mapping := &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Content: []*yaml.Node{{Kind: keyNode.Kind, Tag: keyNode.Tag, Value: keyNode.Value}, valueNode},
+	}
+
+	writer := new(bytes.Buffer)
+
+	encoder := yaml.NewEncoder(writer)
+	if indent != 0 {
+		encoder.SetIndent(indent)
+	}
+
+	if err := encoder.Encode(mapping); err != nil {
+		return "", fmt.Errorf("failed to marshal yaml nodes: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(writer.String(), "\n"), "\n")
+	if keyNode.HeadComment != "" {
+		lines = append([]string{strings.TrimSuffix(keyNode.HeadComment, "\n")}, lines...)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// nodeForValue marshals an arbitrary value into a yaml.Node, returns nil, false if the value can't be represented
+func nodeForValue(value any) (*yaml.Node, bool) {
+	var valueNode yaml.Node
+	if b, marshalErr := yaml.Marshal(value); marshalErr != nil {
+		return nil, false
+	} else if unmarshalErr := yaml.Unmarshal(b, &valueNode); unmarshalErr != nil {
+		return nil, false
+	} else if len(valueNode.Content) == 0 {
+		return nil, false
+	}
+
+	return valueNode.Content[0], true
+}
+
+// setScalarValue sets the value, tag and style of the scalar node in such a way that decoding the node results in
+// the given value again. Strings that would otherwise be read as another type, such as "yes", "0755" or "null", are
+// quoted and values of a string schema are always rendered as a string.
+func setScalarValue(node *yaml.Node, value any, schemaType string) {
+	valueNode, ok := nodeForValue(value)
+	if !ok || valueNode.Kind != yaml.ScalarNode {
+		node.Value = fmt.Sprint(value)
+		return
+	}
+
+	node.Value = valueNode.Value
+	node.Tag = valueNode.Tag
+	node.Style = valueNode.Style
+
+	// setting the tag to !!str makes the encoder quote the value if it would resolve to another type
+	if schemaType == "string" && node.Tag != strTag {
+		node.Tag = strTag
+		node.Style = 0
+	}
+}
+
+// withObjectDefault pushes the keys of the default value of an object schema down to its properties, as the default
+// of the object takes precedence over the defaults of the individual properties. Keys that are not defined as a
+// property are added as a property with the additionalProperties schema or, if there is none, a schema derived from
+// the value.
+func withObjectDefault(schema *jsonschema.Schema) *jsonschema.Schema {
+	defaults, ok := asMapStringAny(schema.Default)
+	if !ok || len(defaults) == 0 {
+		return schema
+	}
+
+	properties := make(jsonschema.SchemaMap, len(defaults))
+	if schema.Properties != nil {
+		maps.Copy(properties, *schema.Properties)
+	}
+
+	additional, hasAdditional := additionalSchema(schema)
+
+	for key, value := range defaults {
+		property := properties[key]
+
+		// keys that are neither a property nor match a pattern are described by additionalProperties
+		if property == nil && hasAdditional && len(patternPropertiesForProperty(schema, key)) == 0 {
+			property = additional
+		}
+
+		properties[key] = withDefaultValue(property, value)
+	}
+
+	result := *schema
+	result.Properties = &properties
+
+	return &result
+}
+
+// withDefaultValue returns a copy of the (resolved) schema with the given default value. If the schema is nil, a schema
+// with a type that matches the value is returned.
+func withDefaultValue(schema *jsonschema.Schema, value any) *jsonschema.Schema {
+	if schema == nil {
+		return &jsonschema.Schema{Type: jsonschema.SchemaType{typeOfValue(value)}, Default: value}
+	}
+
+	result := *resolveRef(schema)
+	result.Default = value
+
+	return &result
+}
+
+// withConstValue returns a copy of the schema in which the const value is the default, as no other value is
+// allowed. If the schema doesn't declare a type, the type of the const value is used.
+func withConstValue(schema *jsonschema.Schema) *jsonschema.Schema {
+	if !withConst(schema) {
+		return schema
+	}
+
+	result := *schema
+	result.Default = schema.Const.Value
+
+	if len(result.Type) == 0 {
+		result.Type = jsonschema.SchemaType{typeOfValue(schema.Const.Value)}
+	}
+
+	return &result
+}
+
+// typeOfValue returns the JSON schema type of the given value
+func typeOfValue(value any) string {
+	if _, isMap := asMapStringAny(value); isMap {
+		return "object"
+	}
+
+	if _, isSlice := asSliceAny(value); isSlice {
+		return "array"
+	}
+
+	switch value.(type) {
+	case nil:
+		return NullValue
+	case bool:
+		return "boolean"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "integer"
+	case float32, float64:
+		return "number"
+	default:
+		return "string"
+	}
 }
 
 // resolve returns a new slice in which schemas that are references are replaced with the resolved reference
@@ -267,6 +794,254 @@ func resolve(schemas []*jsonschema.Schema) []*jsonschema.Schema {
 	return res
 }
 
+// selectType returns the type of the schema that should be rendered. This is the first type that matches the override
+// value or, in absence of an override, the default value. If neither matches, the first type that isn't null is used.
+func selectType(schema *jsonschema.Schema, cfg *Config) string {
+	if len(schema.Type) == 1 {
+		return schema.Type[0]
+	}
+
+	value, hasValue := cfg.currentValue()
+	if !hasValue && schema.Default != nil {
+		value, hasValue = schema.Default, true
+	}
+
+	if hasValue {
+		for _, schemaType := range schema.Type {
+			if matchesType(schemaType, value) {
+				return schemaType
+			}
+		}
+	}
+
+	for _, schemaType := range schema.Type {
+		if schemaType != NullValue {
+			return schemaType
+		}
+	}
+
+	return schema.Type[0]
+}
+
+// matchesType returns true if the value is of the given JSON schema type, where numbers without a fractional part
+// are considered integers as well
+func matchesType(schemaType string, value any) bool {
+	valueType := typeOfValue(value)
+
+	switch {
+	case schemaType == valueType:
+		return true
+	case schemaType == "number":
+		return valueType == "integer"
+	case schemaType == "integer" && valueType == "number":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	default:
+		return false
+	}
+}
+
+// formatTypes lists the types of a schema that allows more than one type (besides null), returns an empty string
+// otherwise.
+func formatTypes(schema *jsonschema.Schema) string {
+	if len(slices.DeleteFunc(slices.Clone(schema.Type), func(t string) bool { return t == NullValue })) < 2 { //nolint:mnd // a single type needs no explanation
+		return ""
+	}
+
+	return "Types: " + strings.Join(schema.Type, " | ")
+}
+
+// formatEnum lists the values of an enum
+func formatEnum(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		if value == nil {
+			formatted[i] = NullValue
+		} else {
+			formatted[i] = fmt.Sprint(value)
+		}
+	}
+
+	return "Allowed: " + strings.Join(formatted, " | ")
+}
+
+// formatConstraints summarises the validation constraints of the schema on a single line, for example
+// `Range: 1..65535, format: hostname`. Returns an empty string if the schema has no constraints.
+func formatConstraints(schema *jsonschema.Schema) string {
+	var constraints []string
+
+	if bounds := formatBounds(schema.Minimum, schema.ExclusiveMinimum, schema.Maximum, schema.ExclusiveMaximum); bounds != "" {
+		constraints = append(constraints, "range: "+bounds)
+	}
+
+	if schema.MultipleOf != nil {
+		constraints = append(constraints, "multiple of: "+formatRat(schema.MultipleOf))
+	}
+
+	if bounds := formatBounds(ratOf(schema.MinLength), nil, ratOf(schema.MaxLength), nil); bounds != "" {
+		constraints = append(constraints, "length: "+bounds)
+	}
+
+	if schema.Pattern != nil {
+		constraints = append(constraints, "pattern: "+*schema.Pattern)
+	}
+
+	if schema.Format != nil {
+		constraints = append(constraints, "format: "+*schema.Format)
+	}
+
+	if bounds := formatBounds(ratOf(schema.MinItems), nil, ratOf(schema.MaxItems), nil); bounds != "" {
+		constraints = append(constraints, "items: "+bounds)
+	}
+
+	if schema.UniqueItems != nil && *schema.UniqueItems {
+		constraints = append(constraints, "unique items")
+	}
+
+	if len(constraints) == 0 {
+		return ""
+	}
+
+	result := strings.Join(constraints, ", ")
+
+	return strings.ToUpper(result[:1]) + result[1:]
+}
+
+// formatBounds formats a lower and upper bound as `min..max` if both are inclusive, otherwise the bounds are listed
+// separately (e.g. `> 0, <= 10`). Returns an empty string if there are no bounds.
+func formatBounds(minimum, exclusiveMinimum, maximum, exclusiveMaximum *jsonschema.Rat) string {
+	if minimum != nil && maximum != nil && exclusiveMinimum == nil && exclusiveMaximum == nil {
+		return formatRat(minimum) + ".." + formatRat(maximum)
+	}
+
+	var bounds []string
+
+	for _, bound := range []struct {
+		operator string
+		value    *jsonschema.Rat
+	}{
+		{operator: ">= ", value: minimum},
+		{operator: "> ", value: exclusiveMinimum},
+		{operator: "<= ", value: maximum},
+		{operator: "< ", value: exclusiveMaximum},
+	} {
+		if bound.value != nil {
+			bounds = append(bounds, bound.operator+formatRat(bound.value))
+		}
+	}
+
+	return strings.Join(bounds, ", ")
+}
+
+// ratOf converts the float keywords (like minLength) to a jsonschema.Rat
+func ratOf(value *float64) *jsonschema.Rat {
+	if value == nil {
+		return nil
+	}
+
+	return &jsonschema.Rat{Rat: new(big.Rat).SetFloat64(*value)}
+}
+
+// formatRat formats the number without trailing zeroes
+func formatRat(value *jsonschema.Rat) string {
+	if value.Rat == nil {
+		return "0"
+	}
+
+	if value.IsInt() {
+		return value.Num().String()
+	}
+
+	result, _ := value.Float64()
+
+	return strconv.FormatFloat(result, 'f', -1, 64)
+}
+
+// branches returns the subschemas of oneOf and anyOf, in that order
+func branches(schema *jsonschema.Schema) []*jsonschema.Schema {
+	return slices.Concat(schema.OneOf, schema.AnyOf)
+}
+
+// selectBranch returns the first anyOf/oneOf branch that the override values of the config validate against. If
+// there are no override values or none of the branches match, the first branch is returned. Returns nil, false
+// if the schema has no branches at all.
+func selectBranch(schema *jsonschema.Schema, cfg *Config) (*jsonschema.Schema, bool) {
+	candidates := branches(schema)
+	if len(candidates) == 0 {
+		return nil, false
+	}
+
+	if value, ok := cfg.currentValue(); ok {
+		for _, candidate := range candidates {
+			if candidate.Validate(value).IsValid() {
+				return candidate, true
+			}
+		}
+	}
+
+	return candidates[0], true
+}
+
+// branchLabel returns a short human-readable name for an anyOf/oneOf branch, preferring the title, then the
+// name of the reference and finally the type(s) and properties of the branch.
+func branchLabel(branch *jsonschema.Schema, index int) string {
+	if branch.Title != nil && *branch.Title != "" {
+		return *branch.Title
+	}
+
+	if branch.Ref != "" {
+		return branch.Ref[strings.LastIndex(branch.Ref, "/")+1:]
+	}
+
+	if len(branch.Type) == 0 {
+		return fmt.Sprintf("option %d", index+1)
+	}
+
+	label := strings.Join(branch.Type, " | ")
+	if branch.Properties != nil && len(*branch.Properties) > 0 {
+		label += " (" + strings.Join(slices.Sorted(maps.Keys(*branch.Properties)), ", ") + ")"
+	}
+
+	return label
+}
+
+// formatAlternatives lists the anyOf/oneOf branches of the schema and marks the one that was selected for
+// rendering.
+func formatAlternatives(schema *jsonschema.Schema, selected *jsonschema.Schema) string {
+	var builder strings.Builder
+
+	if len(schema.OneOf) > 0 {
+		builder.WriteString("One of:\n")
+	} else {
+		builder.WriteString("Any of:\n")
+	}
+
+	for i, branch := range branches(schema) {
+		builder.WriteString("- " + branchLabel(branch, i))
+
+		if branch == selected {
+			builder.WriteString(" (selected)")
+		}
+
+		builder.WriteRune('\n')
+	}
+
+	return builder.String()
+}
+
+// joinComments concatenates the non-empty comment blocks, separated by an empty comment line
+func joinComments(comments ...string) string {
+	blocks := make([]string, 0, len(comments))
+
+	for _, comment := range comments {
+		if comment = strings.TrimSuffix(comment, "\n"); comment != "" {
+			blocks = append(blocks, comment)
+		}
+	}
+
+	return strings.Join(blocks, "\n#\n")
+}
+
 // patternPropertiesForProperty returns matching pattern properties sorted in alphabetical order for some property name
 func patternPropertiesForProperty(schema *jsonschema.Schema, propertyName string) []*jsonschema.Schema {
 	patterns := schema.PatternProperties
@@ -287,11 +1062,43 @@ func patternPropertiesForProperty(schema *jsonschema.Schema, propertyName string
 	return result
 }
 
-// formatHeadComment will generate the comment above the property with the description
+// formatDocumentation formats the title, description and examples as a head comment, each is taken from the first
+// schema that defines it. The title is taken from the schemas themselves rather than the effective (documented)
+// schemas, as the title of an anyOf/oneOf branch is already listed with the alternatives.
+func formatDocumentation(schemas []*jsonschema.Schema, documented []*jsonschema.Schema, lineLength uint) string {
+	var (
+		title, description string
+		examples           []any
+	)
+
+	if schema, ok := coalesce(schemas, withTitle); ok {
+		title = *schema.Title
+	}
+
+	if schema, ok := coalesce(documented, withDescription); ok {
+		description = *schema.Description
+	}
+
+	if schema, ok := coalesce(documented, withExamples); ok {
+		examples = schema.Examples
+	}
+
+	return formatHeadComment(title, description, examples, lineLength)
+}
+
+// formatHeadComment will generate the comment above the property with the title, description
 // and example values. The description will be word-wrapped in case it exceeds the given non-zero lineLength.
-func formatHeadComment(description string, examples []any, lineLength uint) string {
+func formatHeadComment(title string, description string, examples []any, lineLength uint) string {
 	var builder strings.Builder
 
+	if title != "" {
+		builder.WriteString(title)
+
+		if description != "" || len(examples) > 0 {
+			builder.WriteRune('\n')
+		}
+	}
+
 	if description != "" {
 		if lineLength > 0 {
 			description = wordwrap.WrapString(description, lineLength)
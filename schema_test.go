@@ -394,3 +394,1406 @@ func TestResolve_EmptySlice(t *testing.T) {
 	// Assert
 	assert.Empty(t, resolved)
 }
+
+func TestScheYAML_RendersFirstBranchOfOneOf(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "source": {
+      "description": "Where to load the data from",
+      "oneOf": [
+        {"$ref": "#/$defs/File"},
+        {
+          "type": "object",
+          "properties": {
+            "url": {"type": "string", "default": "https://example.com"}
+          },
+          "required": ["url"]
+        }
+      ]
+    }
+  },
+  "$defs": {
+    "File": {
+      "type": "object",
+      "properties": {
+        "path": {"type": "string", "default": "/tmp/data"}
+      },
+      "required": ["path"]
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# Where to load the data from
+#
+# One of:
+# - File (selected)
+# - object (url)
+source:
+    path: /tmp/data
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	// First test the data itself, and quit if it isn't as expected.
+	require.YAMLEq(t, expectedData, string(actualData))
+
+	// If the properties are as expected, test the comments
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_RendersBranchMatchingOverrides(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "source": {
+      "anyOf": [
+        {
+          "title": "file",
+          "type": "object",
+          "properties": {
+            "path": {"type": "string", "default": "/tmp/data"}
+          },
+          "required": ["path"]
+        },
+        {
+          "title": "http",
+          "type": "object",
+          "properties": {
+            "url": {"type": "string"},
+            "timeout": {"type": "integer", "default": 30}
+          },
+          "required": ["url"]
+        }
+      ]
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.ValueOverrides = map[string]any{
+		"source": map[string]any{"url": "https://example.com"},
+	}
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# Any of:
+# - file
+# - http (selected)
+source:
+    timeout: 30
+    url: https://example.com
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	// First test the data itself, and quit if it isn't as expected.
+	require.YAMLEq(t, expectedData, string(actualData))
+
+	// If the properties are as expected, test the comments
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_SummarisesBranchesOfRoot(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "description": "Where to load the data from",
+  "oneOf": [
+    {
+      "type": "object",
+      "properties": {
+        "path": {"type": "string", "default": "/tmp/data"}
+      }
+    },
+    {
+      "type": "object",
+      "properties": {
+        "url": {"type": "string"}
+      },
+      "required": ["url"]
+    }
+  ]
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# Where to load the data from
+#
+# One of:
+# - object (path) (selected)
+# - object (url)
+path: /tmp/data
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	// First test the data itself, and quit if it isn't as expected.
+	require.YAMLEq(t, expectedData, string(actualData))
+
+	// If the properties are as expected, test the comments
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_MergesAllOfMembers(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "required": ["server"],
+  "properties": {
+    "server": {
+      "description": "The server to run",
+      "allOf": [
+        {"$ref": "#/$defs/Base"},
+        {
+          "type": "object",
+          "properties": {
+            "port": {"type": "integer", "default": 8080, "description": "Port to listen on"},
+            "host": {"description": "Overridden by the base"}
+          },
+          "required": ["port"]
+        }
+      ]
+    }
+  },
+  "$defs": {
+    "Base": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost", "description": "Host to listen on"}
+      },
+      "required": ["host"]
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.OnlyRequired = true
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# The server to run
+server:
+    # Host to listen on
+    host: localhost
+    # Port to listen on
+    port: 8080
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	// First test the data itself, and quit if it isn't as expected.
+	require.YAMLEq(t, expectedData, string(actualData))
+
+	// If the properties are as expected, test the comments
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_AppliesConditionalSubschemas(t *testing.T) {
+	t.Parallel()
+
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "mode": {"type": "string", "enum": ["plain", "tls"], "default": "plain"}
+  },
+  "if": {
+    "properties": {"mode": {"const": "tls"}}
+  },
+  "then": {
+    "properties": {
+      "cert_path": {"type": "string", "default": "/etc/tls", "description": "Path to the certificate"}
+    },
+    "required": ["cert_path"]
+  },
+  "else": {
+    "properties": {
+      "port": {"type": "integer", "default": 80}
+    }
+  }
+}`
+
+	tests := map[string]struct {
+		overrides map[string]any
+
+		expected string
+	}{
+		"else is applied on defaults": {
+			overrides: map[string]any{},
+
+			expected: "# Allowed: plain | tls\nmode: plain\nport: 80\n",
+		},
+		"then is applied on override": {
+			overrides: map[string]any{"mode": "tls"},
+
+			expected: "# Path to the certificate\ncert_path: /etc/tls\n# Allowed: plain | tls\nmode: tls\n",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			compiler := jsonschema.NewCompiler()
+			schema, err := compiler.Compile([]byte(inputData))
+			require.NoError(t, err)
+
+			cfg := NewConfig()
+			cfg.ValueOverrides = testData.overrides
+
+			// Act
+			result, err := scheYAML(schema, cfg)
+
+			// Assert
+			require.NoError(t, err)
+
+			// Raw YAML from the node
+			actualData, err := yaml.Marshal(&result)
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, string(actualData))
+		})
+	}
+}
+
+func TestScheYAML_RendersObjectAndArrayDefaults(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "retry": {
+      "type": "object",
+      "default": {"retries": 3, "backoff": {"initial": "1s"}},
+      "properties": {
+        "retries": {"type": "integer", "default": 1},
+        "timeout": {"type": "string", "default": "30s"},
+        "backoff": {
+          "type": "object",
+          "properties": {
+            "initial": {"type": "string"},
+            "max": {"type": "string", "default": "1m"}
+          }
+        }
+      }
+    },
+    "tags": {
+      "type": "array",
+      "items": {"type": "string"},
+      "default": ["a", "b"]
+    },
+    "labels": {
+      "type": "string",
+      "default": {"team": "core"}
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.ValueOverrides = map[string]any{
+		"retry": map[string]any{"timeout": "10s"},
+	}
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `labels:
+    team: core
+retry:
+    backoff:
+        initial: 1s
+        max: 1m
+    retries: 3
+    timeout: 10s
+tags:
+    - a
+    - b
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	// First test the data itself, and quit if it isn't as expected.
+	require.YAMLEq(t, expectedData, string(actualData))
+
+	// If the properties are as expected, test the comments
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_UsesPropertyOrder(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := []byte(`{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "default": "app"},
+    "advanced": {
+      "type": "object",
+      "properties": {
+        "workers": {"type": "integer", "default": 4},
+        "debug": {"type": "boolean", "default": false}
+      }
+    }
+  }
+}`)
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile(inputData)
+	require.NoError(t, err)
+
+	document, err := NewSchemaDocument(schema, inputData)
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.PropertyOrder = SchemaOrder(document)
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := "name: app\nadvanced:\n    workers: 4\n    debug: false\n"
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_RendersScalarsThatDecodeToTheSameValue(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "yes": {"type": "string", "default": "yes"},
+    "mode": {"type": "string", "default": "0755"},
+    "null": {"type": "string", "default": "null"},
+    "exponent": {"type": "string", "default": "1e3"},
+    "number": {"type": "string", "default": 12},
+    "float": {"type": "number", "default": 4.5},
+    "integer": {"type": "integer", "default": 3},
+    "boolean": {"type": "boolean", "default": true},
+    "override": {"type": "string"},
+    "nullOverride": {"type": ["string", "null"]}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.ValueOverrides = map[string]any{
+		"override":     "on",
+		"nullOverride": NullValue,
+	}
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	var actual map[string]any
+	require.NoError(t, yaml.Unmarshal(actualData, &actual))
+
+	expected := map[string]any{
+		"yes":          "yes",
+		"mode":         "0755",
+		"null":         "null",
+		"exponent":     "1e3",
+		"number":       "12",
+		"float":        4.5,
+		"integer":      3,
+		"boolean":      true,
+		"override":     "on",
+		"nullOverride": nil,
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestScheYAML_RendersTypeMatchingTheValue(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "nullFirst": {"type": ["null", "string"], "default": "abc"},
+    "nullOverride": {"type": ["null", "string"]},
+    "integerOrString": {"type": ["integer", "string"], "default": "auto", "description": "Amount of workers"},
+    "objectOrBoolean": {
+      "type": ["object", "boolean"],
+      "properties": {
+        "enabled": {"type": "boolean", "default": true}
+      }
+    },
+    "booleanOrObject": {
+      "type": ["boolean", "object"],
+      "properties": {
+        "enabled": {"type": "boolean", "default": true}
+      }
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.ValueOverrides = map[string]any{
+		"nullOverride":    nil,
+		"booleanOrObject": map[string]any{"enabled": false},
+	}
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# Types: boolean | object
+booleanOrObject:
+    enabled: false
+# Amount of workers
+#
+# Types: integer | string
+integerOrString: auto
+nullFirst: abc
+nullOverride: null
+# Types: object | boolean
+objectOrBoolean:
+    enabled: true
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	// First test the data itself, and quit if it isn't as expected.
+	require.YAMLEq(t, expectedData, string(actualData))
+
+	// If the properties are as expected, test the comments
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_RendersRecursiveSchemas(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "$defs": {
+    "Node": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string", "default": "node"},
+        "children": {"type": "array", "items": {"$ref": "#/$defs/Node"}}
+      }
+    }
+  },
+  "type": "object",
+  "properties": {
+    "root": {"$ref": "#/$defs/Node"}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.ValueOverrides = map[string]any{
+		"root": map[string]any{
+			"children": []any{
+				map[string]any{"name": "leaf"},
+			},
+		},
+	}
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `root:
+    children:
+        - children:
+            - null # recursive: see #/$defs/Node
+          name: leaf
+    name: node
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	// First test the data itself, and quit if it isn't as expected.
+	require.YAMLEq(t, expectedData, string(actualData))
+
+	// If the properties are as expected, test the comments
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_ReturnsErrorOnMaxDepth(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "$defs": {
+    "Node": {
+      "type": "object",
+      "properties": {
+        "child": {"$ref": "#/$defs/Node"},
+        "name": {"type": "string"}
+      }
+    }
+  },
+  "$ref": "#/$defs/Node"
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.MaxDepth = 1
+	cfg.ValueOverrides = map[string]any{
+		"child": map[string]any{
+			"child": map[string]any{"name": "too deep"},
+		},
+	}
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	assert.Nil(t, result)
+
+	var maxDepthErr *MaxDepthError
+	require.ErrorAs(t, err, &maxDepthErr)
+	assert.Equal(t, "child.child", maxDepthErr.Path)
+	assert.Equal(t, uint(1), maxDepthErr.MaxDepth)
+}
+
+func TestScheYAML_ReturnsErrorOnCircularReference(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "$defs": {
+    "A": {"$ref": "#/$defs/B"},
+    "B": {"$ref": "#/$defs/A"}
+  },
+  "type": "object",
+  "properties": {
+    "a": {"$ref": "#/$defs/A"}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	// Act
+	result, err := scheYAML(schema, NewConfig())
+
+	// Assert
+	assert.Nil(t, result)
+	require.ErrorIs(t, err, ErrParsing)
+}
+
+func TestScheYAML_RendersEnumAndConst(t *testing.T) {
+	t.Parallel()
+
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "apiVersion": {"const": "v1"},
+    "kind": {"type": "string", "const": "Config", "default": "Other"},
+    "log_level": {"type": "string", "enum": ["debug", "info", "warn"], "description": "The level to log at"},
+    "retries": {"type": "integer", "enum": [1, 3, 5], "default": 3}
+  }
+}`
+
+	tests := map[string]struct {
+		options []Option
+
+		expected string
+	}{
+		"enum values are listed": {
+			options: nil,
+
+			expected: `apiVersion: v1
+kind: Config
+# The level to log at
+#
+# Allowed: debug | info | warn
+log_level: null # TODO: Fill this in
+# Allowed: 1 | 3 | 5
+retries: 3
+`,
+		},
+		"first enum value is a placeholder": {
+			options: []Option{WithEnumPlaceholder()},
+
+			expected: `apiVersion: v1
+kind: Config
+# The level to log at
+#
+# Allowed: debug | info | warn
+log_level: debug # TODO: Fill this in
+# Allowed: 1 | 3 | 5
+retries: 3
+`,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			compiler := jsonschema.NewCompiler()
+			schema, err := compiler.Compile([]byte(inputData))
+			require.NoError(t, err)
+
+			cfg := NewConfig()
+			for _, option := range testData.options {
+				option(cfg)
+			}
+
+			// Act
+			result, err := scheYAML(schema, cfg)
+
+			// Assert
+			require.NoError(t, err)
+
+			// Raw YAML from the node
+			actualData, err := yaml.Marshal(&result)
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, string(actualData))
+		})
+	}
+}
+
+func TestScheYAML_AddsConstraintComments(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "host": {"type": "string", "format": "hostname", "minLength": 1, "maxLength": 253, "default": "localhost"},
+    "port": {"type": "integer", "minimum": 1, "maximum": 65535, "default": 8080, "description": "Port to listen on"},
+    "ratio": {"type": "number", "exclusiveMinimum": 0, "maximum": 1, "multipleOf": 0.25, "default": 0.5},
+    "tags": {"type": "array", "items": {"type": "string", "pattern": "^[a-z]+$"}, "minItems": 1, "uniqueItems": true, "default": ["a"]},
+    "name": {"type": "string", "default": "abc"}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.ConstraintComments = true
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# Length: 1..253, format: hostname
+host: localhost
+name: abc
+# Port to listen on
+#
+# Range: 1..65535
+port: 8080
+# Range: > 0, <= 1, multiple of: 0.25
+ratio: 0.5
+# Items: >= 1, unique items
+tags:
+    - a
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_RendersTitles(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "$defs": {
+    "Database": {
+      "title": "Database",
+      "description": "Connection settings",
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost"}
+      }
+    }
+  },
+  "title": "Service configuration",
+  "description": "Configuration of the service",
+  "type": "object",
+  "properties": {
+    "database": {"$ref": "#/$defs/Database"},
+    "name": {"title": "Name", "type": "string", "default": "abc", "examples": ["def"]}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.OutputHeader = "yaml-language-server: $schema=schema.json"
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# yaml-language-server: $schema=schema.json
+#
+# Service configuration
+# Configuration of the service
+# Database
+# Connection settings
+database:
+    host: localhost
+# Name
+# Examples:
+# - def
+name: abc
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_RendersOptionalPropertiesAsComments(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "required": ["name", "server", "users"],
+  "properties": {
+    "database": {
+      "type": "object",
+      "description": "Database settings",
+      "properties": {
+        "host": {"type": "string", "default": "localhost", "description": "Host of the database"},
+        "port": {"type": "integer", "default": 5432}
+      }
+    },
+    "name": {"type": "string", "default": "service"},
+    "port": {"type": "integer", "default": 8080, "description": "Port to listen on"},
+    "server": {
+      "type": "object",
+      "properties": {
+        "timeout": {"type": "integer", "default": 30}
+      }
+    },
+    "users": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {
+          "name": {"type": "string", "default": "admin"},
+          "role": {"type": "string", "default": "viewer"}
+        }
+      }
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.OptionalAsComments = true
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `# Database settings
+# database:
+#     # Host of the database
+#     host: localhost
+#     port: 5432
+name: service
+# Port to listen on
+# port: 8080
+server: {
+    # timeout: 30
+}
+users:
+    - name: admin
+      # role: viewer
+`
+
+	// Raw YAML from the node
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	// only the required properties remain once the comments are left out
+	require.YAMLEq(t, "{name: service, server: {}, users: [{name: admin}]}", string(actualData))
+
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_RendersAnnotations(t *testing.T) {
+	t.Parallel()
+
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "id": {"type": "string", "readOnly": true, "default": "generated"},
+    "name": {"type": "string", "default": "service"},
+    "password": {"type": "string", "writeOnly": true, "default": "hunter2"},
+    "port": {"type": "integer", "deprecated": true, "default": 8080, "description": "Use listen instead"}
+  }
+}`
+
+	tests := map[string]struct {
+		options   []Option
+		overrides map[string]any
+
+		expected string
+	}{
+		"default": {
+			expected: `name: service
+password: <secret>
+# DEPRECATED
+# Use listen instead
+port: 8080
+`,
+		},
+		"options": {
+			options: []Option{SkipDeprecated(), IncludeReadOnly(), WithWriteOnlyPlaceholder("<password>")},
+
+			expected: `id: generated
+name: service
+password: <password>
+`,
+		},
+		"include write-only": {
+			options: []Option{SkipDeprecated(), IncludeWriteOnly()},
+
+			expected: `name: service
+password: hunter2
+`,
+		},
+		"overrides": {
+			options:   []Option{SkipDeprecated()},
+			overrides: map[string]any{"id": "abc", "password": "letmein", "port": 80},
+
+			expected: `id: abc
+name: service
+password: <secret>
+# DEPRECATED
+# Use listen instead
+port: 80
+`,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			compiler := jsonschema.NewCompiler()
+			schema, err := compiler.Compile([]byte(inputData))
+			require.NoError(t, err)
+
+			cfg := NewConfig()
+			for _, option := range testData.options {
+				option(cfg)
+			}
+
+			if testData.overrides != nil {
+				cfg.ValueOverrides = testData.overrides
+			}
+
+			// Act
+			result, err := scheYAML(schema, cfg)
+
+			// Assert
+			require.NoError(t, err)
+
+			actualData, err := yaml.Marshal(&result)
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, string(actualData))
+		})
+	}
+}
+
+func TestScheYAML_ReplacesWriteOnlyItems(t *testing.T) {
+	t.Parallel()
+
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "tokens": {"type": "array", "items": {"type": "string", "writeOnly": true}, "default": ["abc"]},
+    "keys": {"type": "array", "items": {"type": "string", "writeOnly": true}}
+  }
+}`
+
+	tests := map[string]struct {
+		overrides map[string]any
+
+		expected string
+	}{
+		"defaults": {
+			expected: `keys:
+    - <secret>
+tokens:
+    - <secret>
+`,
+		},
+		"overrides": {
+			overrides: map[string]any{"keys": []any{"def", "ghi"}},
+
+			expected: `keys:
+    - <secret>
+    - <secret>
+tokens:
+    - <secret>
+`,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			compiler := jsonschema.NewCompiler()
+			schema, err := compiler.Compile([]byte(inputData))
+			require.NoError(t, err)
+
+			cfg := NewConfig()
+			if testData.overrides != nil {
+				cfg.ValueOverrides = testData.overrides
+			}
+
+			// Act
+			result, err := scheYAML(schema, cfg)
+
+			// Assert
+			require.NoError(t, err)
+
+			actualData, err := yaml.Marshal(&result)
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, string(actualData))
+		})
+	}
+}
+
+func TestScheYAML_RendersAdditionalProperties(t *testing.T) {
+	t.Parallel()
+
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "services": {
+      "type": "object",
+      "properties": {
+        "enabled": {"type": "boolean", "default": true}
+      },
+      "additionalProperties": {"$ref": "#/$defs/Service"}
+    }
+  },
+  "$defs": {
+    "Service": {
+      "type": "object",
+      "description": "A service to run",
+      "properties": {
+        "image": {"type": "string", "description": "Image of the service"},
+        "replicas": {"type": "integer", "default": 1}
+      }
+    }
+  }
+}`
+
+	tests := map[string]struct {
+		overrides map[string]any
+
+		expected string
+	}{
+		"example entry": {
+			expected: `services:
+    enabled: true
+    # A service to run
+    # <name>:
+    #     # Image of the service
+    #     image: null # TODO: Fill this in
+    #     replicas: 1
+`,
+		},
+		"overrides": {
+			overrides: map[string]any{
+				"services": map[string]any{
+					"api":    map[string]any{"image": "api:latest"},
+					"worker": map[string]any{"image": "worker:latest", "replicas": 3},
+				},
+			},
+
+			expected: `services:
+    # A service to run
+    api:
+        # Image of the service
+        image: api:latest
+        replicas: 1
+    enabled: true
+    # A service to run
+    worker:
+        # Image of the service
+        image: worker:latest
+        replicas: 3
+`,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			compiler := jsonschema.NewCompiler()
+			schema, err := compiler.Compile([]byte(inputData))
+			require.NoError(t, err)
+
+			cfg := NewConfig()
+			if testData.overrides != nil {
+				cfg.ValueOverrides = testData.overrides
+			}
+
+			// Act
+			result, err := scheYAML(schema, cfg)
+
+			// Assert
+			require.NoError(t, err)
+
+			actualData, err := yaml.Marshal(&result)
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, string(actualData))
+		})
+	}
+}
+
+func TestScheYAML_RendersExampleOfMapWithoutProperties(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "services": {
+      "type": "object",
+      "additionalProperties": {"$ref": "#/$defs/Service"}
+    }
+  },
+  "$defs": {
+    "Service": {
+      "type": "object",
+      "description": "A service to run",
+      "properties": {
+        "image": {"type": "string"},
+        "replicas": {"type": "integer", "default": 1}
+      }
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	// Act
+	result, err := scheYAML(schema, NewConfig())
+
+	// Assert
+	require.NoError(t, err)
+
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	expectedData := `services: {
+    # A service to run
+    # <name>:
+    #     image: null # TODO: Fill this in
+    #     replicas: 1
+}
+`
+
+	// the example is commented out, so the map itself is empty
+	require.YAMLEq(t, "services: {}\n", string(actualData))
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_RendersDefaultOfAdditionalProperties(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "limits": {
+      "type": "object",
+      "default": {"cpu": 2},
+      "additionalProperties": {"type": "integer", "description": "Limit of the resource", "maximum": 8}
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.ValueOverrides = map[string]any{"limits": map[string]any{"memory": 4}}
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.NoError(t, err)
+
+	expectedData := `limits:
+    # Limit of the resource
+    cpu: 2
+    # Limit of the resource
+    memory: 4
+`
+
+	actualData, err := yaml.Marshal(&result)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedData, string(actualData))
+}
+
+func TestScheYAML_RendersArrayKeywords(t *testing.T) {
+	t.Parallel()
+
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "point": {
+      "type": "array",
+      "prefixItems": [
+        {"type": "number", "description": "Latitude", "default": 52.1},
+        {"type": "number", "description": "Longitude", "default": 5.1}
+      ],
+      "items": false
+    },
+    "replicas": {
+      "type": "array",
+      "minItems": 2,
+      "items": {"type": "string", "default": "node"}
+    },
+    "roles": {
+      "type": "array",
+      "items": {"type": "string"},
+      "contains": {"const": "admin"}
+    },
+    "tags": {
+      "type": "array",
+      "maxItems": 2,
+      "items": {"type": "string"}
+    }
+  }
+}`
+
+	tests := map[string]struct {
+		options   []Option
+		overrides map[string]any
+
+		expected string
+	}{
+		"placeholders": {
+			expected: `point:
+    # Latitude
+    - 52.1
+    # Longitude
+    - 5.1
+replicas:
+    - node
+    - node
+roles:
+    - admin
+tags:
+    - null # TODO: Fill this in
+`,
+		},
+		"empty arrays": {
+			options: []Option{WithEmptyArrays()},
+
+			expected: `point:
+    # Latitude
+    - 52.1
+    # Longitude
+    - 5.1
+replicas:
+    - node
+    - node
+roles:
+    - admin
+tags: []
+`,
+		},
+		"overrides": {
+			overrides: map[string]any{
+				"point": []any{1.5},
+				"tags":  []any{"a", "b"},
+			},
+
+			expected: `point:
+    # Latitude
+    - 1.5
+replicas:
+    - node
+    - node
+roles:
+    - admin
+tags:
+    - a
+    - b
+`,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			compiler := jsonschema.NewCompiler()
+			schema, err := compiler.Compile([]byte(inputData))
+			require.NoError(t, err)
+
+			cfg := NewConfig()
+			for _, option := range testData.options {
+				option(cfg)
+			}
+
+			if testData.overrides != nil {
+				cfg.ValueOverrides = testData.overrides
+			}
+
+			// Act
+			result, err := scheYAML(schema, cfg)
+
+			// Assert
+			require.NoError(t, err)
+
+			actualData, err := yaml.Marshal(&result)
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, string(actualData))
+		})
+	}
+}
+
+func TestScheYAML_ReturnsErrorOnTooManyItems(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.ValueOverrides = map[string]any{"tags": []any{"a", "b", "c"}}
+
+	// Act
+	result, err := scheYAML(schema, cfg)
+
+	// Assert
+	require.ErrorIs(t, err, ErrInvalidInput)
+	require.ErrorContains(t, err, "tags: 3 items exceed the maximum of 2")
+	assert.Nil(t, result)
+}
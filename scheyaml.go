@@ -2,9 +2,11 @@ package scheyaml
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
+	"reflect"
 	"slices"
 	"strings"
 
@@ -12,6 +14,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// defaultJSONIndent is the number of spaces used to indent the output of SchemaToJSON
+const defaultJSONIndent = 2
+
 // ErrInvalidInput is returned if given parameters are invalid
 var ErrInvalidInput = errors.New("invalid input given")
 
@@ -30,6 +35,20 @@ func (e InvalidSchemaError) Error() string {
 	return builder.String()
 }
 
+// MaxDepthError is returned when the override values nest a recursive schema deeper than allowed, see WithMaxDepth
+type MaxDepthError struct {
+	// Path to the property at which the maximum depth was exceeded
+	Path string
+
+	// MaxDepth that was configured
+	MaxDepth uint
+}
+
+// Error describes the path that exceeded the maximum depth
+func (e MaxDepthError) Error() string {
+	return fmt.Sprintf("%s: recursive schema nested deeper than the maximum depth of %d", e.Path, e.MaxDepth)
+}
+
 // SchemaToYAML will take the given JSON schema and turn it into an example YAML file using fields like
 // `description` and `examples` for documentation, `default` for default values and `properties` for listing blocks.
 //
@@ -82,8 +101,24 @@ func SchemaToNode(schema *jsonschema.Schema, opts ...Option) (*yaml.Node, error)
 		opt(config)
 	}
 
+	if len(config.PathOverrides) > 0 {
+		overrides, err := expandPaths(schema, config.ValueOverrides, config.PathOverrides)
+		if err != nil {
+			return nil, err
+		}
+
+		config.ValueOverrides = overrides
+
+		if config.ProvenanceComments {
+			if config.overrideOrigins, err = pathOrigins(schema, config.PathOverrides); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if !config.SkipValidate {
-		res := schema.Validate(config.ValueOverrides)
+		// keys that are skipped are not part of the result, so they're not validated either
+		res := schema.Validate(withoutSkipped(config.ValueOverrides))
 		if errs := res.Errors; errs != nil {
 			return nil, &InvalidSchemaError{Errors: errs}
 		}
@@ -91,3 +126,122 @@ func SchemaToNode(schema *jsonschema.Schema, opts ...Option) (*yaml.Node, error)
 
 	return scheYAML(schema, config)
 }
+
+// SchemaToValue resolves the default values, override values and pattern properties of the schema the same way as
+// SchemaToYAML, but returns the resulting document as a map instead of YAML. Properties without a value are nil and
+// comments, such as optional properties that are commented out, are not part of the result. Arrays without values
+// are empty lists, rather than the placeholder item that SchemaToYAML would add, and the values of writeOnly
+// properties are included unless WithWriteOnlyPlaceholder is given.
+//
+// You may provide options to customise the output.
+func SchemaToValue(schema *jsonschema.Schema, opts ...Option) (map[string]any, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema is nil: %w", ErrInvalidInput)
+	}
+
+	rootNode, err := SchemaToNode(schema, resolvingOptions(opts)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scheyaml schema: %w", err)
+	}
+
+	result := make(map[string]any)
+
+	// a schema without a type results in an empty node
+	if rootNode.Kind == 0 {
+		return result, nil
+	}
+
+	if err := rootNode.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode yaml nodes: %w", err)
+	}
+
+	return result, nil
+}
+
+// resolvingOptions returns a copy of the options for functions that resolve the values rather than document them, in
+// which the values of writeOnly properties are included by default and placeholder items are left out
+func resolvingOptions(opts []Option) []Option {
+	return slices.Concat([]Option{IncludeWriteOnly()}, opts, []Option{withoutPlaceholders()})
+}
+
+// SchemaToJSON is a version of SchemaToValue that returns the resulting document as indented JSON, using the
+// indent of WithIndent or two spaces by default.
+//
+// You may provide options to customise the output.
+func SchemaToJSON(schema *jsonschema.Schema, opts ...Option) ([]byte, error) {
+	value, err := SchemaToValue(schema, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	config := NewConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	indent := defaultJSONIndent
+	if config.Indent > 0 {
+		indent = config.Indent
+	}
+
+	result, err := json.MarshalIndent(value, "", strings.Repeat(" ", indent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	return append(result, '\n'), nil
+}
+
+// withoutSkipped returns a copy of the values in which the keys that are set to SkipValue are removed, nested objects
+// included
+func withoutSkipped(values map[string]any) map[string]any {
+	result := make(map[string]any, len(values))
+
+	for key, value := range values {
+		if _, shouldSkip := value.(skipValue); shouldSkip {
+			continue
+		}
+
+		if nested, isMap := asMapStringAny(value); isMap {
+			value = withoutSkipped(nested)
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// Unmarshal decodes the YAML data into out, which must be a non-nil pointer, after filling in the values that are
+// missing in data as described in FillYAML. This ensures the default values of the schema are applied to structs
+// generated from the schema. The data is validated against the schema unless SkipValidate is given. Arrays without
+// values are decoded as empty lists, rather than the placeholder item that FillYAML would add, and the values of
+// writeOnly properties are decoded as-is.
+//
+// You may provide options to customise the output.
+func Unmarshal(schema *jsonschema.Schema, data []byte, out any, opts ...Option) error {
+	if schema == nil {
+		return fmt.Errorf("schema is nil: %w", ErrInvalidInput)
+	}
+
+	if value := reflect.ValueOf(out); value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("out must be a non-nil pointer: %w", ErrInvalidInput)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	// placeholder items of arrays are documentation, they're not decoded as values
+	result, err := FillNode(schema, &document, resolvingOptions(opts)...)
+	if err != nil {
+		return fmt.Errorf("failed to scheyaml data: %w", err)
+	}
+
+	if err := result.Decode(out); err != nil {
+		return fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	return nil
+}
@@ -148,3 +148,345 @@ func TestSchemaToYAML_InvalidSchema(t *testing.T) {
 	assert.NotEmpty(t, actual.Errors)
 	assert.Nil(t, result)
 }
+
+func TestUnmarshal_AppliesDefaults(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "default": "Hello World"},
+    "port": {"type": "integer", "default": 8080},
+    "tags": {"type": "array", "items": {"type": "string"}, "default": ["a", "b"]}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	type config struct {
+		Name string   `yaml:"name"`
+		Port int      `yaml:"port"`
+		Tags []string `yaml:"tags"`
+	}
+
+	var result config
+
+	// Act
+	err = Unmarshal(schema, []byte("port: 9090\n"), &result)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, config{Name: "Hello World", Port: 9090, Tags: []string{"a", "b"}}, result)
+}
+
+func TestUnmarshal_DoesNotDecodePlaceholderItems(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "servers": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "host": {"type": "string"},
+          "port": {"type": "integer", "default": 80}
+        }
+      }
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	type server struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	}
+
+	type config struct {
+		Servers []server `yaml:"servers"`
+	}
+
+	tests := map[string]struct {
+		data string
+
+		expected config
+	}{
+		"empty document": {
+			data: "",
+
+			expected: config{Servers: []server{}},
+		},
+		"empty mapping": {
+			data: "{}\n",
+
+			expected: config{Servers: []server{}},
+		},
+		"existing items": {
+			data: "servers: [{host: example.com}]\n",
+
+			expected: config{Servers: []server{{Host: "example.com", Port: 80}}},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var result config
+
+			// Act
+			err := Unmarshal(schema, []byte(testData.data), &result)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestUnmarshal_ReturnsErrorOnInvalidData(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(`{"type": "object", "properties": {"port": {"type": "integer"}}}`))
+	require.NoError(t, err)
+
+	var result map[string]any
+
+	// Act
+	err = Unmarshal(schema, []byte("port: abc\n"), &result)
+
+	// Assert
+	var actual *InvalidSchemaError
+	require.ErrorAs(t, err, &actual)
+	assert.Nil(t, result)
+}
+
+func TestUnmarshal_ReturnsErrorOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(`{"type": "object"}`))
+	require.NoError(t, err)
+
+	var result map[string]any
+
+	tests := map[string]struct {
+		schema *jsonschema.Schema
+		out    any
+	}{
+		"nil schema": {
+			schema: nil,
+			out:    &result,
+		},
+		"nil out": {
+			schema: schema,
+			out:    nil,
+		},
+		"out is not a pointer": {
+			schema: schema,
+			out:    result,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			err := Unmarshal(testData.schema, []byte("{}"), testData.out)
+
+			// Assert
+			require.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestSchemaToValue_ReturnsErrorOnNilSchema(t *testing.T) {
+	t.Parallel()
+	// Act
+	result, err := SchemaToValue(nil)
+
+	// Assert
+	require.ErrorIs(t, err, ErrInvalidInput)
+	assert.Nil(t, result)
+}
+
+func TestSchemaToValue_ReturnsSameDocumentAsSchemaToYAML(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData, _ := os.ReadFile(path.Join("testdata", "test-schema-nested-pattern-properties.json"))
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile(inputData)
+	require.NoError(t, err)
+
+	overrides := map[string]any{"lifecycle-name": "scheyaml-acc", "tracing-config": map[string]any{}}
+
+	yamlData, err := SchemaToYAML(schema, WithOverrideValues(overrides), SkipValidate())
+	require.NoError(t, err)
+
+	var expected map[string]any
+	require.NoError(t, yaml.Unmarshal(yamlData, &expected))
+
+	// Act
+	result, err := SchemaToValue(schema, WithOverrideValues(overrides), SkipValidate())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestSchemaToValue_ReturnsEmptyMapWithoutType(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema, err := jsonschema.NewCompiler().Compile([]byte(`{}`))
+	require.NoError(t, err)
+
+	// Act
+	result, err := SchemaToValue(schema)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{}, result)
+}
+
+func TestSchemaToValue_DoesNotReturnPlaceholderItems(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema, err := jsonschema.NewCompiler().Compile([]byte(`{
+  "type": "object",
+  "properties": {
+    "servers": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "host": {"type": "string"},
+          "port": {"type": "integer", "default": 80}
+        }
+      }
+    }
+  }
+}`))
+	require.NoError(t, err)
+
+	// Act
+	result, err := SchemaToValue(schema)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"servers": []any{}}, result)
+}
+
+func TestSchemaToValue_ReturnsWriteOnlyValues(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema, err := jsonschema.NewCompiler().Compile([]byte(`{
+  "type": "object",
+  "properties": {
+    "password": {"type": "string", "writeOnly": true, "default": "hunter2"}
+  }
+}`))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		options []Option
+
+		expected map[string]any
+	}{
+		"default": {
+			expected: map[string]any{"password": "hunter2"},
+		},
+		"placeholder": {
+			options: []Option{WithWriteOnlyPlaceholder("<secret>")},
+
+			expected: map[string]any{"password": "<secret>"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := SchemaToValue(schema, testData.options...)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestSchemaToJSON_ReturnsIndentedJSON(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	schema, err := jsonschema.NewCompiler().Compile([]byte(`{
+  "type": "object",
+  "properties": {
+    "db": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string", "default": "localhost", "description": "Host of the database"},
+        "port": {"type": "integer", "default": 5432},
+        "user": {"type": "string"}
+      }
+    },
+    "tags": {"type": "array", "items": {"type": "string"}, "default": ["a", "b"]}
+  }
+}`))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		options []Option
+
+		expected string
+	}{
+		"default indent": {
+			options: []Option{WithOverrideValues(map[string]any{"db": map[string]any{"port": 6432}})},
+
+			expected: `{
+  "db": {
+    "host": "localhost",
+    "port": 6432,
+    "user": null
+  },
+  "tags": [
+    "a",
+    "b"
+  ]
+}
+`,
+		},
+		"custom indent": {
+			options: []Option{WithIndent(4), WithOverrideValues(map[string]any{"tags": SkipValue})},
+
+			expected: `{
+    "db": {
+        "host": "localhost",
+        "port": 5432,
+        "user": null
+    }
+}
+`,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := SchemaToJSON(schema, testData.options...)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, string(result))
+		})
+	}
+}
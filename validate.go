@@ -0,0 +1,339 @@
+package scheyaml
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kaptinlin/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Diagnostic is a single validation error in a YAML document
+type Diagnostic struct {
+	// Line and Column of the offending key, or of the closest parent that exists if the key is missing
+	Line, Column int
+
+	// Path is the JSON pointer to the offending value, e.g. /database/port
+	Path string
+
+	// Keyword of the schema that failed, e.g. type or required
+	Keyword string
+
+	// Message describing the error
+	Message string
+}
+
+// String formats the diagnostic as line:column: path: message
+func (d Diagnostic) String() string {
+	path := d.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return fmt.Sprintf("%d:%d: %s: %s", d.Line, d.Column, path, d.Message)
+}
+
+// ValidationError is returned when a YAML document does not comply with the schema, see ValidateYAML
+type ValidationError struct {
+	Diagnostics []Diagnostic
+}
+
+// Error is a multiline string of the diagnostics
+func (e ValidationError) Error() string {
+	var builder strings.Builder
+	for _, diagnostic := range e.Diagnostics {
+		builder.WriteString(diagnostic.String() + "\n")
+	}
+
+	return builder.String()
+}
+
+// ValidateYAML validates the YAML document against the schema, if the document is invalid a *ValidationError is
+// returned that contains the line and column of every error. An empty document is validated as an empty object.
+func ValidateYAML(schema *jsonschema.Schema, data []byte) error {
+	if schema == nil {
+		return fmt.Errorf("schema is nil: %w", ErrInvalidInput)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	return ValidateNode(schema, &document)
+}
+
+// ValidateNode is a lower-level version of ValidateYAML that validates an already parsed document
+func ValidateNode(schema *jsonschema.Schema, node *yaml.Node) error {
+	if schema == nil || node == nil {
+		return fmt.Errorf("schema or node is nil: %w", ErrInvalidInput)
+	}
+
+	var value any = map[string]any{}
+
+	if node.Kind != 0 && (node.Kind != yaml.DocumentNode || len(node.Content) > 0) {
+		if err := node.Decode(&value); err != nil {
+			return fmt.Errorf("failed to decode document: %w", err)
+		}
+	}
+
+	result := schema.Validate(value)
+	if result.IsValid() {
+		return nil
+	}
+
+	diagnostics := diagnose(&diagnosis{node: node, schema: schema, value: value}, result, "")
+
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column), strings.Compare(a.Path, b.Path))
+	})
+
+	return &ValidationError{Diagnostics: diagnostics}
+}
+
+// aggregateKeywords report that one or more subschemas failed, which is already reported by their own results
+var aggregateKeywords = []string{
+	"properties", "patternProperties", "additionalProperties", "items", "prefixItems", "dependentSchemas",
+	"unevaluatedProperties", "unevaluatedItems",
+}
+
+// itemKeywords report that one or more items of an array failed without the results of those items, so the items
+// are validated again to find out why
+var itemKeywords = []string{"items", "prefixItems"}
+
+// pointerUnescaper unescapes a segment of a JSON pointer
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// diagnosis contains the document that is being validated, as parsed node and decoded value, and its schema
+type diagnosis struct {
+	node   *yaml.Node
+	schema *jsonschema.Schema
+	value  any
+}
+
+// diagnose turns the errors of the result and its details into diagnostics, the instance locations of the details
+// are relative to their parent so the path is passed along. Errors of values that don't exist in the document are
+// left out, as those are reported by the `required` keyword of the parent. Errors of values that exist but can't be
+// located in the node (e.g. keys provided by a merge key) are reported at the closest parent that can be located.
+func diagnose(d *diagnosis, result *jsonschema.EvaluationResult, parentPath string) []Diagnostic {
+	path := parentPath + result.InstanceLocation
+
+	line, column, found := locate(d.node, path)
+	if _, exists := valueAt(d.value, path); !found && !exists {
+		return nil
+	}
+
+	hasInvalidDetails := slices.ContainsFunc(result.Details, func(detail *jsonschema.EvaluationResult) bool {
+		return !detail.IsValid()
+	})
+
+	var diagnostics []Diagnostic
+
+	for _, keyword := range slices.Sorted(maps.Keys(result.Errors)) {
+		if hasInvalidDetails && slices.Contains(aggregateKeywords, keyword) {
+			continue
+		}
+
+		if slices.Contains(itemKeywords, keyword) && !hasInvalidDetails {
+			if itemDiagnostics := d.diagnoseItems(path); len(itemDiagnostics) > 0 {
+				diagnostics = append(diagnostics, itemDiagnostics...)
+				continue
+			}
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:    line,
+			Column:  column,
+			Path:    path,
+			Keyword: keyword,
+			Message: result.Errors[keyword].Error(),
+		})
+	}
+
+	for _, detail := range result.Details {
+		if !detail.IsValid() {
+			diagnostics = append(diagnostics, diagnose(d, detail, path)...)
+		}
+	}
+
+	return diagnostics
+}
+
+// diagnoseItems validates the items of the array at the path against their own schema, as the validator only
+// reports which items failed without the reason. Items of which the schema can't be determined are left out.
+func (d *diagnosis) diagnoseItems(path string) []Diagnostic {
+	value, _ := valueAt(d.value, path)
+	items, isSlice := asSliceAny(value)
+	if !isSlice {
+		return nil
+	}
+
+	array := schemaAt(d.schema, path)
+
+	var diagnostics []Diagnostic
+
+	for i, item := range items {
+		schema := itemSchema(array, i)
+		if schema == nil {
+			continue
+		}
+
+		if result := schema.Validate(item); !result.IsValid() {
+			diagnostics = append(diagnostics, diagnose(d, result, path+"/"+strconv.Itoa(i))...)
+		}
+	}
+
+	return diagnostics
+}
+
+// schemaAt returns the (effective) schema of the value the JSON pointer refers to, or nil if it's not described by
+// the schema
+func schemaAt(schema *jsonschema.Schema, pointer string) *jsonschema.Schema {
+	for _, segment := range pointerSegments(pointer) {
+		schema = effectiveSchema(schema, NewConfig())
+		if schema == nil {
+			return nil
+		}
+
+		if index, err := strconv.Atoi(segment); err == nil && (schema.Items != nil || len(schema.PrefixItems) > 0) {
+			schema = itemSchema(schema, index)
+			continue
+		}
+
+		_, schema = lookupProperty(schema, segment)
+	}
+
+	return effectiveSchema(schema, NewConfig())
+}
+
+// valueAt returns the value the JSON pointer refers to, or nil, false if it does not exist
+func valueAt(value any, pointer string) (any, bool) {
+	for _, segment := range pointerSegments(pointer) {
+		if values, isMap := asMapStringAny(value); isMap {
+			nested, ok := values[segment]
+			if !ok {
+				return nil, false
+			}
+
+			value = nested
+
+			continue
+		}
+
+		items, isSlice := asSliceAny(value)
+
+		index, err := strconv.Atoi(segment)
+		if !isSlice || err != nil || index < 0 || index >= len(items) {
+			return nil, false
+		}
+
+		value = items[index]
+	}
+
+	return value, true
+}
+
+// pointerSegments splits the JSON pointer into its unescaped segments
+func pointerSegments(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = pointerUnescaper.Replace(segment)
+	}
+
+	return segments
+}
+
+// locate returns the line and column of the key (or item) the JSON pointer refers to, if it does not exist the
+// location of the closest parent is returned together with false
+func locate(node *yaml.Node, pointer string) (int, int, bool) {
+	line, column := 1, 1
+	if node = dereference(node); node != nil && node.Line > 0 {
+		line, column = node.Line, node.Column
+	}
+
+	for _, segment := range pointerSegments(pointer) {
+		node = dereference(node)
+		if node == nil {
+			return line, column, false
+		}
+
+		var next *yaml.Node
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			if key, value := locateKey(node, segment); key != nil {
+				line, column = key.Line, key.Column
+				next = value
+			}
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(node.Content) {
+				next = node.Content[index]
+				line, column = next.Line, next.Column
+			}
+		default:
+		}
+
+		if next == nil {
+			return line, column, false
+		}
+
+		node = next
+	}
+
+	return line, column, true
+}
+
+// locateKey returns the key and value nodes of the key in the mapping node, keys that are provided by a merge key
+// (`<<: *anchor`) are looked up in the anchored mappings. Returns nil, nil if the key does not exist.
+func locateKey(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "<<" {
+			continue
+		}
+
+		merged := []*yaml.Node{node.Content[i+1]}
+		if node.Content[i+1].Kind == yaml.SequenceNode {
+			merged = node.Content[i+1].Content
+		}
+
+		for _, alias := range merged {
+			if target := dereference(alias); target != nil && target.Kind == yaml.MappingNode {
+				if keyNode, valueNode := locateKey(target, key); keyNode != nil {
+					return keyNode, valueNode
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// dereference returns the content of document nodes and the target of alias nodes
+func dereference(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) > 0:
+			node = node.Content[0]
+		case node.Kind == yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+
+	return nil
+}
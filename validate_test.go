@@ -0,0 +1,175 @@
+package scheyaml
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateYAML_ReturnsDiagnosticsWithLocation(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string"},
+    "database": {
+      "type": "object",
+      "properties": {
+        "port": {"type": "integer"},
+        "hosts": {"type": "array", "items": {"type": "object", "required": ["host"]}}
+      }
+    }
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	document := `# My configuration
+database:
+  port: abc
+  hosts:
+    - port: 80
+`
+
+	// Act
+	err = ValidateYAML(schema, []byte(document))
+
+	// Assert
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+
+	expected := []Diagnostic{
+		{Line: 2, Column: 1, Path: "", Keyword: "required", Message: "Required property 'name' is missing"},
+		{Line: 3, Column: 3, Path: "/database/port", Keyword: "type", Message: "Value is string but should be integer"},
+		{Line: 5, Column: 7, Path: "/database/hosts/0", Keyword: "required", Message: "Required property 'host' is missing"},
+	}
+	assert.Equal(t, expected, actual.Diagnostics)
+}
+
+func TestValidateYAML_ReportsValuesOfMergeKeys(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	inputData := `{
+  "type": "object",
+  "properties": {
+    "x": {"type": "object"},
+    "other": {"type": "object", "properties": {"port": {"type": "integer"}}}
+  }
+}`
+
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(inputData))
+	require.NoError(t, err)
+
+	document := `x: &x
+  port: abc
+other:
+  <<: *x
+`
+
+	// Act
+	err = ValidateYAML(schema, []byte(document))
+
+	// Assert
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+
+	expected := []Diagnostic{
+		{Line: 2, Column: 3, Path: "/other/port", Keyword: "type", Message: "Value is string but should be integer"},
+	}
+	assert.Equal(t, expected, actual.Diagnostics)
+}
+
+func TestValidateYAML_ReturnsNilOnValidDocument(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	compiler := jsonschema.NewCompiler()
+	schema, err := compiler.Compile([]byte(`{"type": "object", "properties": {"name": {"type": "string"}}}`))
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"empty document":  "",
+		"only a comment":  "# nothing here\n",
+		"valid document":  "name: abc\n",
+		"unknown present": "name: abc\nother: 1\n",
+	}
+
+	for name, document := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			err := ValidateYAML(schema, []byte(document))
+
+			// Assert
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateYAML_ReturnsErrorOnNilSchema(t *testing.T) {
+	t.Parallel()
+	// Act
+	err := ValidateYAML(nil, []byte("name: abc"))
+
+	// Assert
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestValidationError_Error(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	err := &ValidationError{
+		Diagnostics: []Diagnostic{
+			{Line: 1, Column: 1, Path: "", Message: "message"},
+			{Line: 2, Column: 3, Path: "/a/b", Message: "message"},
+		},
+	}
+
+	// Act
+	message := err.Error()
+
+	// Assert
+	assert.Equal(t, "1:1: /: message\n2:3: /a/b: message\n", message)
+}
+
+func TestLocate_ReturnsExpectedLocation(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var document yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("base: &base\n  a/b: 1\nitems:\n  - *base\nmerged:\n  <<: *base\n"), &document))
+
+	tests := map[string]struct {
+		pointer string
+
+		expectedLine   int
+		expectedColumn int
+		expectedFound  bool
+	}{
+		"root":             {pointer: "", expectedLine: 1, expectedColumn: 1, expectedFound: true},
+		"escaped key":      {pointer: "/base/a~1b", expectedLine: 2, expectedColumn: 3, expectedFound: true},
+		"item":             {pointer: "/items/0", expectedLine: 4, expectedColumn: 5, expectedFound: true},
+		"through an alias": {pointer: "/items/0/a~1b", expectedLine: 2, expectedColumn: 3, expectedFound: true},
+		"missing key":      {pointer: "/items/5/c", expectedLine: 3, expectedColumn: 1, expectedFound: false},
+		"merge key":        {pointer: "/merged/a~1b", expectedLine: 2, expectedColumn: 3, expectedFound: true},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			line, column, found := locate(&document, testData.pointer)
+
+			// Assert
+			assert.Equal(t, testData.expectedLine, line)
+			assert.Equal(t, testData.expectedColumn, column)
+			assert.Equal(t, testData.expectedFound, found)
+		})
+	}
+}